@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Expected outcomes of an assert block.
+const (
+	assertAllowed = "allowed"
+	assertDenied  = "denied"
+)
+
+// ACLAssertModel describes an assert block.
+type ACLAssertModel struct {
+	Command types.List   `tfsdk:"command"`
+	Expect  types.String `tfsdk:"expect"`
+}
+
+// verifyAssertions runs every assert block of the user through ACL DRYRUN on
+// every node and reports the expectations that do not hold.
+func (c *RedisClient) verifyAssertions(ctx context.Context, data *ACLUserResourceModel, diags *diag.Diagnostics) {
+	if len(data.Assert) == 0 {
+		return
+	}
+
+	var unsupported bool
+	errs := c.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		for _, assertion := range data.Assert {
+			var command []string
+			for _, arg := range assertion.Command.Elements() {
+				command = append(command, arg.(types.String).ValueString())
+			}
+
+			args := []interface{}{"ACL", "DRYRUN", data.Name.ValueString()}
+			for _, arg := range command {
+				args = append(args, arg)
+			}
+			result, err := node.Do(ctx, args...).Text()
+			if isDryRunUnsupported(err) {
+				unsupported = true
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			expect := assertion.Expect.ValueString()
+			switch {
+			case expect == assertAllowed && result != "OK":
+				diags.AddError(
+					"Assertion Failed",
+					fmt.Sprintf("Expected ACL user %s to be allowed to run %q on node %s, but it is denied: %s", data.Name.ValueString(), strings.Join(command, " "), node.Options().Addr, result),
+				)
+			case expect == assertDenied && result == "OK":
+				diags.AddError(
+					"Assertion Failed",
+					fmt.Sprintf("Expected ACL user %s to be denied %q on node %s, but it is allowed.", data.Name.ValueString(), strings.Join(command, " "), node.Options().Addr),
+				)
+			}
+		}
+		return nil
+	})
+	if addNodeErrors(diags, errs, "verify ACL user assertions") {
+		return
+	}
+	if unsupported {
+		diags.AddWarning("Assertions Skipped", "ACL DRYRUN requires Redis 7.0 or later, so the assert blocks were not verified.")
+	}
+}
+
+// isDryRunUnsupported reports whether err is the reply of a server that does
+// not know ACL DRYRUN.
+func isDryRunUnsupported(err error) bool {
+	return isUnknownSubcommand(err)
+}
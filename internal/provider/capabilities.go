@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/redis/go-redis/v9"
+)
+
+// Server flavors recognized by detectServer.
+const (
+	flavorRedis     = "redis"
+	flavorValkey    = "valkey"
+	flavorKeyDB     = "keydb"
+	flavorDragonfly = "dragonfly"
+)
+
+// serverInfo describes the server the provider is connected to and the ACL
+// features it supports.
+type serverInfo struct {
+	flavor  string
+	version string
+	// mode is the run mode reported by INFO: standalone, cluster or sentinel.
+	mode string
+	// major and minor are the Redis-compatible version, used to derive the
+	// capabilities of Redis and its forks.
+	major, minor int
+
+	// selectors tells whether ACL selectors are supported.
+	selectors bool
+	// keyPermissions tells whether %R~ and %W~ key permissions are supported.
+	keyPermissions bool
+	// dryRun tells whether ACL DRYRUN is supported.
+	dryRun bool
+}
+
+// String returns the flavor and version, such as "Redis 7.2.4".
+func (s *serverInfo) String() string {
+	name := map[string]string{
+		flavorRedis:     "Redis",
+		flavorValkey:    "Valkey",
+		flavorKeyDB:     "KeyDB",
+		flavorDragonfly: "Dragonfly",
+	}[s.flavor]
+	return name + " " + s.version
+}
+
+// detectServer identifies the server behind client with INFO server and
+// HELLO. Features that could not be probed are reported as warnings in diags.
+func detectServer(ctx context.Context, client redis.UniversalClient, diags *diag.Diagnostics) (*serverInfo, error) {
+	info, err := client.Info(ctx, "server").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// HELLO is unknown before Redis 6.0, in which case INFO is enough
+	var helloServer string
+	if hello, err := client.Do(ctx, "HELLO").Result(); err == nil {
+		if fields, err := fieldMap(hello); err == nil {
+			helloServer, _ = fields["server"].(string)
+		}
+	}
+
+	server, err := newServerInfo(parseInfo(info), helloServer)
+	if err != nil {
+		return nil, err
+	}
+	if server.flavor == flavorDragonfly {
+		if err := server.probeDragonfly(ctx, client); err != nil {
+			diags.AddWarning(
+				"Server Feature Probe Failed",
+				fmt.Sprintf("Unable to check whether %s supports ACL DRYRUN, so assert blocks will only be reported by the server: %s", server, err),
+			)
+		}
+	}
+	return server, nil
+}
+
+// probeDragonfly checks whether Dragonfly, whose Redis compatible version
+// does not tell which ACL commands it implements, supports ACL DRYRUN. The
+// probe runs a dry run of PING as the authenticated user, which changes
+// nothing on the server. When the probe fails for another reason than an
+// unknown command, ACL DRYRUN is assumed to be supported and an error is
+// returned.
+func (s *serverInfo) probeDragonfly(ctx context.Context, client redis.UniversalClient) error {
+	user, err := client.Do(ctx, "ACL", "WHOAMI").Text()
+	if err == nil {
+		err = client.Do(ctx, "ACL", "DRYRUN", user, "PING").Err()
+	}
+	switch {
+	case err == nil:
+		s.dryRun = true
+	case isDryRunUnsupported(err) || isUnknownCommand(err):
+		s.dryRun = false
+	default:
+		s.dryRun = true
+		return err
+	}
+	return nil
+}
+
+// newServerInfo derives the flavor, version and capabilities of a server from
+// its INFO server fields and the server name reported by HELLO.
+func newServerInfo(info map[string]string, helloServer string) (*serverInfo, error) {
+	server := &serverInfo{flavor: flavorRedis, version: info["redis_version"], mode: info["redis_mode"]}
+	if server.mode == "" {
+		server.mode = "standalone"
+	}
+
+	helloServer = strings.ToLower(helloServer)
+	switch {
+	case info["dragonfly_version"] != "" || helloServer == flavorDragonfly:
+		server.flavor = flavorDragonfly
+		if version := info["dragonfly_version"]; version != "" {
+			server.version = strings.TrimPrefix(version, "df-v")
+		}
+	case info["valkey_version"] != "" || info["server_name"] == flavorValkey || helloServer == flavorValkey:
+		server.flavor = flavorValkey
+		if version := info["valkey_version"]; version != "" {
+			server.version = version
+		}
+	case helloServer == flavorKeyDB || strings.Contains(strings.ToLower(info["executable"]), "keydb"):
+		server.flavor = flavorKeyDB
+	}
+
+	if server.version == "" {
+		return nil, fmt.Errorf("INFO server does not report a version")
+	}
+	if _, err := fmt.Sscanf(info["redis_version"], "%d.%d", &server.major, &server.minor); err != nil {
+		return nil, fmt.Errorf("unable to parse version %q: %w", info["redis_version"], err)
+	}
+
+	redis7 := server.major >= 7
+	switch server.flavor {
+	case flavorRedis, flavorValkey:
+		server.selectors = redis7
+		server.keyPermissions = redis7
+		server.dryRun = redis7
+	case flavorDragonfly:
+		// Dragonfly does not implement selectors. Key permissions cannot be
+		// probed without writing to the server, so they are not checked at
+		// plan time and are left to the server. ACL DRYRUN is probed by
+		// detectServer.
+		server.keyPermissions = true
+	}
+	// KeyDB is based on Redis 6 and supports none of them
+
+	return server, nil
+}
+
+// checkCapabilities adds an attribute error for every attribute of data that
+// needs a feature the server does not support.
+func (s *serverInfo) checkCapabilities(data *ACLUserResourceModel, diags *diag.Diagnostics) {
+	unsupported := func(p path.Path, feature string) {
+		diags.AddAttributeError(
+			p,
+			"Unsupported Attribute",
+			fmt.Sprintf("%s requires %s, which the server (%s) does not support.", p, feature, s),
+		)
+	}
+
+	if !s.selectors {
+		if len(data.Selectors.Elements()) > 0 {
+			unsupported(path.Root("selectors"), "ACL selectors (Redis 7.0 or later)")
+		}
+		if len(data.Selector) > 0 {
+			unsupported(path.Root("selector"), "ACL selectors (Redis 7.0 or later)")
+		}
+	}
+
+	if !s.keyPermissions {
+		if len(data.ReadKeys.Elements()) > 0 {
+			unsupported(path.Root("read_keys"), "%R~ key permissions (Redis 7.0 or later)")
+		}
+		if len(data.WriteKeys.Elements()) > 0 {
+			unsupported(path.Root("write_keys"), "%W~ key permissions (Redis 7.0 or later)")
+		}
+		if !data.Keys.IsUnknown() {
+			for _, rule := range strings.Fields(data.Keys.ValueString()) {
+				if strings.HasPrefix(rule, "%") {
+					unsupported(path.Root("keys"), fmt.Sprintf("the %s key permission (Redis 7.0 or later)", rule))
+				}
+			}
+		}
+	}
+
+	if !s.dryRun && len(data.Assert) > 0 {
+		unsupported(path.Root("assert"), "ACL DRYRUN (Redis 7.0 or later)")
+	}
+}
+
+// parseInfo parses the "field:value" lines of an INFO reply.
+func parseInfo(info string) map[string]string {
+	fields := map[string]string{}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
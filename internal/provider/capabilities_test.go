@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInfo(t *testing.T) {
+	info := "# Server\r\nredis_version:7.2.4\r\nredis_mode:standalone\r\nexecutable:/usr/local/bin/redis-server\r\n\r\n"
+
+	assert.Equal(t, map[string]string{
+		"redis_version": "7.2.4",
+		"redis_mode":    "standalone",
+		"executable":    "/usr/local/bin/redis-server",
+	}, parseInfo(info))
+}
+
+func TestNewServerInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        map[string]string
+		helloServer string
+		expected    *serverInfo
+	}{
+		{
+			name:        "Redis 7",
+			info:        map[string]string{"redis_version": "7.2.4", "redis_mode": "cluster"},
+			helloServer: "redis",
+			expected:    &serverInfo{flavor: flavorRedis, version: "7.2.4", mode: "cluster", major: 7, minor: 2, selectors: true, keyPermissions: true, dryRun: true},
+		},
+		{
+			name:        "Redis 6.2",
+			info:        map[string]string{"redis_version": "6.2.14"},
+			helloServer: "redis",
+			expected:    &serverInfo{flavor: flavorRedis, version: "6.2.14", mode: "standalone", major: 6, minor: 2},
+		},
+		{
+			name:     "Redis 5 without HELLO",
+			info:     map[string]string{"redis_version": "5.0.14"},
+			expected: &serverInfo{flavor: flavorRedis, version: "5.0.14", mode: "standalone", major: 5},
+		},
+		{
+			name:        "Valkey 8",
+			info:        map[string]string{"redis_version": "7.2.4", "valkey_version": "8.0.1", "server_name": "valkey"},
+			helloServer: "valkey",
+			expected:    &serverInfo{flavor: flavorValkey, version: "8.0.1", mode: "standalone", major: 7, minor: 2, selectors: true, keyPermissions: true, dryRun: true},
+		},
+		{
+			name:        "Valkey 7.2",
+			info:        map[string]string{"redis_version": "7.2.5", "server_name": "valkey"},
+			helloServer: "redis",
+			expected:    &serverInfo{flavor: flavorValkey, version: "7.2.5", mode: "standalone", major: 7, minor: 2, selectors: true, keyPermissions: true, dryRun: true},
+		},
+		{
+			name:        "KeyDB",
+			info:        map[string]string{"redis_version": "6.3.4", "executable": "/usr/local/bin/keydb-server"},
+			helloServer: "redis",
+			expected:    &serverInfo{flavor: flavorKeyDB, version: "6.3.4", mode: "standalone", major: 6, minor: 3},
+		},
+		{
+			name:        "Dragonfly",
+			info:        map[string]string{"redis_version": "7.4.0", "dragonfly_version": "df-v1.21.2"},
+			helloServer: "redis",
+			expected:    &serverInfo{flavor: flavorDragonfly, version: "1.21.2", mode: "standalone", major: 7, minor: 4, keyPermissions: true},
+		},
+		{
+			// Older releases report a Redis 6 compatible version
+			name:        "Dragonfly 1.10",
+			info:        map[string]string{"redis_version": "6.2.11", "dragonfly_version": "df-v1.10.0"},
+			helloServer: "dragonfly",
+			expected:    &serverInfo{flavor: flavorDragonfly, version: "1.10.0", mode: "standalone", major: 6, minor: 2, keyPermissions: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := newServerInfo(tt.info, tt.helloServer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, server)
+		})
+	}
+
+	_, err := newServerInfo(map[string]string{}, "redis")
+	assert.Error(t, err)
+
+	_, err = newServerInfo(map[string]string{"redis_version": "unstable"}, "redis")
+	assert.Error(t, err)
+}
+
+func TestServerInfoString(t *testing.T) {
+	assert.Equal(t, "Redis 6.2.14", (&serverInfo{flavor: flavorRedis, version: "6.2.14"}).String())
+	assert.Equal(t, "Dragonfly 1.21.2", (&serverInfo{flavor: flavorDragonfly, version: "1.21.2"}).String())
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	strs := func(values ...string) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+	data := &ACLUserResourceModel{
+		Keys:      types.StringValue("~app:* %R~logs:*"),
+		ReadKeys:  types.SetNull(types.StringType),
+		WriteKeys: strs("queue:*"),
+		Selectors: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("~temp:* +get")}),
+		Assert:    []ACLAssertModel{{Command: types.ListNull(types.StringType), Expect: types.StringValue(assertAllowed)}},
+	}
+
+	details := func(server *serverInfo) []string {
+		var diags diag.Diagnostics
+		server.checkCapabilities(data, &diags)
+		var details []string
+		for _, d := range diags {
+			details = append(details, d.Detail())
+		}
+		return details
+	}
+
+	redis6 := &serverInfo{flavor: flavorRedis, version: "6.2.14"}
+	assert.Equal(t, []string{
+		"selectors requires ACL selectors (Redis 7.0 or later), which the server (Redis 6.2.14) does not support.",
+		"write_keys requires %W~ key permissions (Redis 7.0 or later), which the server (Redis 6.2.14) does not support.",
+		"keys requires the %R~logs:* key permission (Redis 7.0 or later), which the server (Redis 6.2.14) does not support.",
+		"assert requires ACL DRYRUN (Redis 7.0 or later), which the server (Redis 6.2.14) does not support.",
+	}, details(redis6))
+
+	dragonfly := &serverInfo{flavor: flavorDragonfly, version: "1.21.2", keyPermissions: true, dryRun: true}
+	assert.Equal(t, []string{
+		"selectors requires ACL selectors (Redis 7.0 or later), which the server (Dragonfly 1.21.2) does not support.",
+	}, details(dragonfly))
+
+	// Older Dragonfly releases without ACL DRYRUN; key permissions are left
+	// to the server
+	oldDragonfly := &serverInfo{flavor: flavorDragonfly, version: "1.10.0", keyPermissions: true}
+	assert.Equal(t, []string{
+		"selectors requires ACL selectors (Redis 7.0 or later), which the server (Dragonfly 1.10.0) does not support.",
+		"assert requires ACL DRYRUN (Redis 7.0 or later), which the server (Dragonfly 1.10.0) does not support.",
+	}, details(oldDragonfly))
+
+	redis7 := &serverInfo{flavor: flavorRedis, version: "7.2.4", selectors: true, keyPermissions: true, dryRun: true}
+	assert.Empty(t, details(redis7))
+}
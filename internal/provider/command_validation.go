@@ -0,0 +1,286 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// commandReference is a command, subcommand or category named in the
+// configuration of a user.
+type commandReference struct {
+	path     path.Path
+	rule     string // the rule as written, such as "+get" or "-@admin"
+	prefix   string // what precedes the name in rule, used in suggestions
+	name     string
+	category bool
+}
+
+// serverCommands are the commands and categories known to a server.
+type serverCommands struct {
+	commands map[string]bool
+	// subcommands lists, for each referenced command that has subcommands,
+	// its subcommands as command|subcommand.
+	subcommands map[string]map[string]bool
+	categories  map[string]bool
+}
+
+// commandReferences returns every command, subcommand and category named in
+// the command rules of data: commands, selectors, the command sets and the
+// selector blocks. Unknown values are skipped.
+func commandReferences(data *ACLUserResourceModel) []commandReference {
+	var refs []commandReference
+
+	if !data.Commands.IsNull() && !data.Commands.IsUnknown() {
+		refs = append(refs, ruleReferences(path.Root("commands"), data.Commands.ValueString())...)
+	}
+	for i, selector := range data.Selectors.Elements() {
+		if value, ok := selector.(types.String); ok && !value.IsUnknown() && !value.IsNull() {
+			refs = append(refs, ruleReferences(path.Root("selectors").AtListIndex(i), value.ValueString())...)
+		}
+	}
+	for i, selector := range data.Selector {
+		if !selector.Commands.IsNull() && !selector.Commands.IsUnknown() {
+			refs = append(refs, ruleReferences(path.Root("selector").AtListIndex(i).AtName("commands"), selector.Commands.ValueString())...)
+		}
+	}
+
+	for _, attribute := range []struct {
+		name     string
+		set      types.Set
+		category bool
+	}{
+		{"allowed_commands", data.AllowedCommands, false},
+		{"denied_commands", data.DeniedCommands, false},
+		{"allowed_subcommands", data.AllowedSubcommands, false},
+		{"allowed_categories", data.AllowedCategories, true},
+		{"denied_categories", data.DeniedCategories, true},
+	} {
+		for _, element := range attribute.set.Elements() {
+			value, ok := element.(types.String)
+			if !ok || value.IsUnknown() || value.IsNull() {
+				continue
+			}
+			refs = append(refs, commandReference{
+				path:     path.Root(attribute.name).AtSetValue(value),
+				rule:     value.ValueString(),
+				name:     strings.ToLower(value.ValueString()),
+				category: attribute.category,
+			})
+		}
+	}
+
+	return refs
+}
+
+// ruleReferences returns the commands and categories named by the +/- rules
+// of an ACL rule string, which may be a selector in parentheses.
+func ruleReferences(p path.Path, rules string) []commandReference {
+	var refs []commandReference
+	for _, rule := range strings.Fields(rules) {
+		rule = strings.TrimSuffix(strings.TrimPrefix(rule, "("), ")")
+		if len(rule) < 2 || (rule[0] != '+' && rule[0] != '-') {
+			continue
+		}
+
+		ref := commandReference{path: p, rule: rule, prefix: rule[:1], name: strings.ToLower(rule[1:])}
+		if strings.HasPrefix(ref.name, "@") {
+			ref.prefix = rule[:2]
+			ref.name = ref.name[1:]
+			ref.category = true
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// loadServerCommands returns the commands and categories the server knows,
+// along with the subcommands of the commands referenced as
+// command|subcommand. They are read once per provider instance with COMMAND
+// LIST, ACL CAT and COMMAND INFO and cached on the client, which must be
+// locked by the caller.
+func (c *RedisClient) loadServerCommands(ctx context.Context, refs []commandReference) (*serverCommands, error) {
+	if c.commands == nil {
+		known, err := c.listServerCommands(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.commands = known
+	}
+	known := c.commands
+
+	var parents []string
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		parent, _, ok := strings.Cut(ref.name, "|")
+		if !ok || ref.category || !known.commands[parent] || seen[parent] {
+			continue
+		}
+		if _, loaded := known.subcommands[parent]; !loaded {
+			parents = append(parents, parent)
+		}
+		seen[parent] = true
+	}
+	if len(parents) > 0 {
+		args := []interface{}{"COMMAND", "INFO"}
+		for _, parent := range parents {
+			args = append(args, parent)
+		}
+		info, err := c.client.Do(ctx, args...).Slice()
+		if err != nil {
+			return nil, err
+		}
+		commands, err := parseCommandInfo(info)
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			// Commands without subcommands are cached with an empty set
+			subcommands := map[string]bool{}
+			if command, ok := commands[parent]; ok {
+				for _, subcommand := range command.Subcommands.Elements() {
+					subcommands[subcommand.(types.String).ValueString()] = true
+				}
+			}
+			known.subcommands[parent] = subcommands
+		}
+	}
+
+	return known, nil
+}
+
+// listServerCommands reads the commands and categories the server knows with
+// COMMAND LIST and ACL CAT.
+func (c *RedisClient) listServerCommands(ctx context.Context) (*serverCommands, error) {
+	known := &serverCommands{
+		commands:    map[string]bool{},
+		subcommands: map[string]map[string]bool{},
+		categories:  map[string]bool{"all": true},
+	}
+
+	names, err := c.client.Do(ctx, "COMMAND", "LIST").StringSlice()
+	if isUnknownSubcommand(err) {
+		// COMMAND LIST only exists since Redis 7.0
+		var info []interface{}
+		info, err = c.client.Do(ctx, "COMMAND").Slice()
+		if err == nil {
+			var commands map[string]*CommandModel
+			commands, err = parseCommandInfo(info)
+			names = names[:0]
+			for name := range commands {
+				names = append(names, name)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		known.commands[strings.ToLower(name)] = true
+	}
+
+	categories, err := c.commandCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		known.categories[category] = true
+	}
+
+	return known, nil
+}
+
+// check adds an attribute error for every reference the server does not
+// know, suggesting the closest known name.
+func (s *serverCommands) check(refs []commandReference, diags *diag.Diagnostics) {
+	for _, ref := range refs {
+		var (
+			kind, summary, suffix string
+			candidates            map[string]bool
+		)
+		parent, subcommand, isSubcommand := strings.Cut(ref.name, "|")
+		switch {
+		case ref.category:
+			kind, summary, candidates = "ACL category", "Unknown ACL Category", s.categories
+		case isSubcommand && !s.commands[parent]:
+			kind, summary, candidates = "command", "Unknown Command", s.commands
+			ref.name, suffix = parent, "|"+subcommand
+		case isSubcommand:
+			// Commands without subcommands accept first-argument rules
+			// such as +select|0.
+			if len(s.subcommands[parent]) == 0 {
+				continue
+			}
+			kind, summary, candidates = "subcommand", "Unknown Subcommand", s.subcommands[parent]
+		default:
+			kind, summary, candidates = "command", "Unknown Command", s.commands
+		}
+		if candidates[ref.name] {
+			continue
+		}
+
+		message := fmt.Sprintf("The rule %q refers to the %s %q, which the server does not know.", ref.rule, kind, ref.name)
+		if suggestion := closestName(ref.name, candidates); suggestion != "" {
+			message += fmt.Sprintf(" Did you mean %q?", ref.prefix+suggestion+suffix)
+		}
+		diags.AddAttributeError(ref.path, summary, message)
+	}
+}
+
+// closestName returns the candidate closest to name, or an empty string when
+// no candidate is close enough to be a likely typo.
+func closestName(name string, candidates map[string]bool) string {
+	sorted := make([]string, 0, len(candidates))
+	for candidate := range candidates {
+		sorted = append(sorted, candidate)
+	}
+	sort.Strings(sorted)
+
+	maxDistance := 2
+	if len(name) <= 3 {
+		maxDistance = 1
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range sorted {
+		if distance := editDistance(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the Levenshtein distance where swapping two adjacent characters counts
+// as a single edit.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
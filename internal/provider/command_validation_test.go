@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandReferences(t *testing.T) {
+	data := &ACLUserResourceModel{
+		Commands:           types.StringValue("-@all +GET +config|get ~foo allcommands"),
+		Selectors:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("(~bar* +set -@dangerous)")}),
+		AllowedCommands:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("hget")}),
+		DeniedCommands:     types.SetNull(types.StringType),
+		AllowedCategories:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("read"), types.StringUnknown()}),
+		DeniedCategories:   types.SetNull(types.StringType),
+		AllowedSubcommands: types.SetNull(types.StringType),
+		Selector: []ACLSelectorModel{
+			{Commands: types.StringValue("+del")},
+			{Commands: types.StringUnknown()},
+		},
+	}
+
+	var names []string
+	for _, ref := range commandReferences(data) {
+		if ref.category {
+			names = append(names, "@"+ref.name)
+		} else {
+			names = append(names, ref.name)
+		}
+	}
+	assert.Equal(t, []string{"@all", "get", "config|get", "set", "@dangerous", "del", "hget", "@read"}, names)
+
+	refs := commandReferences(data)
+	assert.Equal(t, path.Root("commands"), refs[1].path)
+	assert.Equal(t, "+GET", refs[1].rule)
+	assert.Equal(t, path.Root("selectors").AtListIndex(0), refs[3].path)
+	assert.Equal(t, "-@", refs[4].prefix)
+	assert.Equal(t, path.Root("selector").AtListIndex(0).AtName("commands"), refs[5].path)
+	assert.Equal(t, path.Root("allowed_commands").AtSetValue(types.StringValue("hget")), refs[6].path)
+	assert.Equal(t, "", refs[6].prefix)
+}
+
+func TestServerCommandsCheck(t *testing.T) {
+	known := &serverCommands{
+		commands:    map[string]bool{"get": true, "set": true, "config": true, "select": true},
+		subcommands: map[string]map[string]bool{"config": {"config|get": true, "config|set": true}, "select": {}},
+		categories:  map[string]bool{"all": true, "read": true, "write": true},
+	}
+
+	tests := []struct {
+		name     string
+		rules    string
+		expected []string
+	}{
+		{name: "known", rules: "-@all +get +@read +config|get +select|0", expected: nil},
+		{name: "unknown command", rules: "+get1", expected: []string{`The rule "+get1" refers to the command "get1", which the server does not know. Did you mean "+get"?`}},
+		{name: "unknown category", rules: "-@reed", expected: []string{`The rule "-@reed" refers to the ACL category "reed", which the server does not know. Did you mean "-@read"?`}},
+		{name: "unknown subcommand", rules: "+config|gte", expected: []string{`The rule "+config|gte" refers to the subcommand "config|gte", which the server does not know. Did you mean "+config|get"?`}},
+		{name: "unknown parent", rules: "+confg|get", expected: []string{`The rule "+confg|get" refers to the command "confg", which the server does not know. Did you mean "+config|get"?`}},
+		{name: "no close match", rules: "+flushall", expected: []string{`The rule "+flushall" refers to the command "flushall", which the server does not know.`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			known.check(ruleReferences(path.Root("commands"), tt.rules), &diags)
+
+			var messages []string
+			for _, d := range diags {
+				messages = append(messages, d.Detail())
+			}
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestClosestName(t *testing.T) {
+	candidates := map[string]bool{"get": true, "set": true, "getex": true, "hget": true}
+
+	assert.Equal(t, "get", closestName("gte", candidates))
+	// Short names only tolerate a single edit
+	assert.Equal(t, "", closestName("gxx", candidates))
+	assert.Equal(t, "get", closestName("get1", candidates))
+	assert.Equal(t, "getex", closestName("getexx", candidates))
+	assert.Equal(t, "", closestName("flushall", candidates))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 0, editDistance("get", "get"))
+	assert.Equal(t, 1, editDistance("reda", "read"))
+}
@@ -0,0 +1,344 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ACLLogDataSource{}
+
+// aclLogReasons are the reasons ACL LOG reports for an entry.
+var aclLogReasons = []string{"auth", "command", "key", "channel"}
+
+func NewACLLogDataSource() datasource.DataSource {
+	return &ACLLogDataSource{}
+}
+
+// ACLLogDataSource defines the data source implementation.
+type ACLLogDataSource struct {
+	redisClient *RedisClient
+}
+
+// ACLLogDataSourceModel describes the data source data model.
+type ACLLogDataSourceModel struct {
+	MaxEntries   types.Int64        `tfsdk:"max_entries"`
+	Username     types.String       `tfsdk:"username"`
+	Reason       types.String       `tfsdk:"reason"`
+	MinTimestamp types.Int64        `tfsdk:"min_timestamp"`
+	Entries      []ACLLogEntryModel `tfsdk:"entries"`
+}
+
+// ACLLogEntryModel describes a single ACL LOG entry.
+type ACLLogEntryModel struct {
+	Node                 types.String  `tfsdk:"node"`
+	EntryID              types.Int64   `tfsdk:"entry_id"`
+	Count                types.Int64   `tfsdk:"count"`
+	Reason               types.String  `tfsdk:"reason"`
+	Context              types.String  `tfsdk:"context"`
+	Object               types.String  `tfsdk:"object"`
+	Username             types.String  `tfsdk:"username"`
+	ClientInfo           types.String  `tfsdk:"client_info"`
+	AgeSeconds           types.Float64 `tfsdk:"age_seconds"`
+	TimestampCreated     types.Int64   `tfsdk:"timestamp_created"`
+	TimestampLastUpdated types.Int64   `tfsdk:"timestamp_last_updated"`
+}
+
+func (d *ACLLogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log"
+}
+
+func (d *ACLLogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets the security events recorded by `ACL LOG`, such as denied commands and failed authentications, " +
+			"from every node. Entries are sorted by the time they were last updated, most recent first.",
+
+		Attributes: map[string]schema.Attribute{
+			"max_entries": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of entries to read from each node. Defaults to the Redis default of 10.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Only return entries for this username.",
+				Optional:            true,
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "Only return entries with this reason: `auth`, `command`, `key` or `channel`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(aclLogReasons...),
+				},
+			},
+			"min_timestamp": schema.Int64Attribute{
+				MarkdownDescription: "Only return entries last updated at or after this Unix time in milliseconds.",
+				Optional:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching ACL LOG entries.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node": schema.StringAttribute{
+							MarkdownDescription: "The address of the node that recorded the entry.",
+							Computed:            true,
+						},
+						"entry_id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the entry, unique per node.",
+							Computed:            true,
+						},
+						"count": schema.Int64Attribute{
+							MarkdownDescription: "The number of similar events grouped into the entry.",
+							Computed:            true,
+						},
+						"reason": schema.StringAttribute{
+							MarkdownDescription: "Why the event was logged: `auth`, `command`, `key` or `channel`.",
+							Computed:            true,
+						},
+						"context": schema.StringAttribute{
+							MarkdownDescription: "Where the event happened, such as `toplevel`, `multi` or `lua`.",
+							Computed:            true,
+						},
+						"object": schema.StringAttribute{
+							MarkdownDescription: "The command, key or channel that was denied.",
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "The user that triggered the event.",
+							Computed:            true,
+						},
+						"client_info": schema.StringAttribute{
+							MarkdownDescription: "The client that triggered the event, in `CLIENT LIST` format.",
+							Computed:            true,
+						},
+						"age_seconds": schema.Float64Attribute{
+							MarkdownDescription: "The age of the entry in seconds.",
+							Computed:            true,
+						},
+						"timestamp_created": schema.Int64Attribute{
+							MarkdownDescription: "When the entry was created, as a Unix time in milliseconds.",
+							Computed:            true,
+						},
+						"timestamp_last_updated": schema.Int64Attribute{
+							MarkdownDescription: "When the entry was last updated, as a Unix time in milliseconds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ACLLogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.redisClient = redisClient
+}
+
+func (d *ACLLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ACLLogDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.redisClient.mutex.Lock()
+	defer d.redisClient.mutex.Unlock()
+
+	args := []interface{}{"ACL", "LOG"}
+	if !data.MaxEntries.IsNull() {
+		args = append(args, data.MaxEntries.ValueInt64())
+	}
+
+	data.Entries = []ACLLogEntryModel{}
+	errs := d.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		result, err := node.Do(ctx, args...).Slice()
+		if err != nil {
+			return err
+		}
+		entries, err := parseACLLog(result, node.Options().Addr)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if aclLogEntryMatches(&data, &entry) {
+				data.Entries = append(data.Entries, entry)
+			}
+		}
+		return nil
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "read ACL log") {
+		return
+	}
+
+	sortACLLogEntries(data.Entries)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// aclLogEntryMatches reports whether entry passes the filters of data.
+func aclLogEntryMatches(data *ACLLogDataSourceModel, entry *ACLLogEntryModel) bool {
+	if !data.Username.IsNull() && entry.Username.ValueString() != data.Username.ValueString() {
+		return false
+	}
+	if !data.Reason.IsNull() && entry.Reason.ValueString() != data.Reason.ValueString() {
+		return false
+	}
+	if !data.MinTimestamp.IsNull() && entry.TimestampLastUpdated.ValueInt64() < data.MinTimestamp.ValueInt64() {
+		return false
+	}
+	return true
+}
+
+// sortACLLogEntries sorts entries gathered from several nodes, most recently
+// updated first.
+func sortACLLogEntries(entries []ACLLogEntryModel) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.TimestampLastUpdated.ValueInt64() != b.TimestampLastUpdated.ValueInt64() {
+			return a.TimestampLastUpdated.ValueInt64() > b.TimestampLastUpdated.ValueInt64()
+		}
+		return a.Node.ValueString() < b.Node.ValueString()
+	})
+}
+
+// parseACLLog parses the reply of ACL LOG sent to the node at addr.
+func parseACLLog(result []interface{}, addr string) ([]ACLLogEntryModel, error) {
+	entries := make([]ACLLogEntryModel, 0, len(result))
+	for _, raw := range result {
+		entry, err := parseACLLogEntry(raw)
+		if err != nil {
+			return nil, err
+		}
+		entry.Node = types.StringValue(addr)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parseACLLogEntry parses a single ACL LOG entry, which is a flat list of
+// field names and values with RESP2 and a map with RESP3.
+func parseACLLogEntry(raw interface{}) (ACLLogEntryModel, error) {
+	var fields []interface{}
+	switch entry := raw.(type) {
+	case []interface{}:
+		fields = entry
+	case map[interface{}]interface{}:
+		for k, v := range entry {
+			fields = append(fields, k, v)
+		}
+	default:
+		return ACLLogEntryModel{}, fmt.Errorf("unexpected ACL LOG entry type %T", raw)
+	}
+
+	entry := ACLLogEntryModel{
+		Node:                 types.StringNull(),
+		EntryID:              types.Int64Null(),
+		Count:                types.Int64Null(),
+		Reason:               types.StringNull(),
+		Context:              types.StringNull(),
+		Object:               types.StringNull(),
+		Username:             types.StringNull(),
+		ClientInfo:           types.StringNull(),
+		AgeSeconds:           types.Float64Null(),
+		TimestampCreated:     types.Int64Null(),
+		TimestampLastUpdated: types.Int64Null(),
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			return ACLLogEntryModel{}, fmt.Errorf("ACL LOG field name is not a string")
+		}
+		value := fields[i+1]
+
+		var err error
+		switch key {
+		case "count":
+			entry.Count, err = logInt64(value)
+		case "entry-id":
+			entry.EntryID, err = logInt64(value)
+		case "timestamp-created":
+			entry.TimestampCreated, err = logInt64(value)
+		case "timestamp-last-updated":
+			entry.TimestampLastUpdated, err = logInt64(value)
+		case "age-seconds":
+			entry.AgeSeconds, err = logFloat64(value)
+		case "reason":
+			entry.Reason = types.StringValue(fmt.Sprint(value))
+		case "context":
+			entry.Context = types.StringValue(fmt.Sprint(value))
+		case "object":
+			entry.Object = types.StringValue(fmt.Sprint(value))
+		case "username":
+			entry.Username = types.StringValue(fmt.Sprint(value))
+		case "client-info":
+			entry.ClientInfo = types.StringValue(fmt.Sprint(value))
+		}
+		if err != nil {
+			return ACLLogEntryModel{}, fmt.Errorf("invalid ACL LOG field %s: %w", key, err)
+		}
+	}
+	return entry, nil
+}
+
+func logInt64(value interface{}) (types.Int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return types.Int64Value(v), nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return types.Int64Null(), err
+		}
+		return types.Int64Value(n), nil
+	}
+	return types.Int64Null(), fmt.Errorf("unexpected type %T", value)
+}
+
+func logFloat64(value interface{}) (types.Float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return types.Float64Value(v), nil
+	case int64:
+		return types.Float64Value(float64(v)), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return types.Float64Null(), err
+		}
+		return types.Float64Value(f), nil
+	}
+	return types.Float64Null(), fmt.Errorf("unexpected type %T", value)
+}
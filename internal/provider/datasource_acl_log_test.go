@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccACLLogDataSource_FailedAuth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			ctx := context.Background()
+			if err := CreateTestUser(ctx, "log_user", "testpass"); err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			// A failed authentication is recorded in the ACL log
+			if client, err := NewUserClient(ctx, "log_user", "wrongpass"); err == nil {
+				_ = client.Close()
+				t.Fatal("Expected authentication with a wrong password to fail")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLLogDataSourceConfig("log_user", "auth"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redisacl_log.test", "entries.#", "1"),
+					resource.TestCheckResourceAttr("data.redisacl_log.test", "entries.0.reason", "auth"),
+					resource.TestCheckResourceAttr("data.redisacl_log.test", "entries.0.username", "log_user"),
+					resource.TestCheckResourceAttrSet("data.redisacl_log.test", "entries.0.node"),
+					resource.TestCheckResourceAttrSet("data.redisacl_log.test", "entries.0.client_info"),
+					resource.TestCheckResourceAttrSet("data.redisacl_log.test", "entries.0.timestamp_created"),
+				),
+			},
+			{
+				Config: testAccACLLogDataSourceConfig("log_user", "command"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redisacl_log.test", "entries.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccACLLogDataSourceConfig(username, reason string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+data "redisacl_log" "test" {
+  username = %[1]q
+  reason   = %[2]q
+}
+`, username, reason)
+}
+
+func TestParseACLLogEntry(t *testing.T) {
+	expected := ACLLogEntryModel{
+		Node:                 types.StringNull(),
+		EntryID:              types.Int64Value(3),
+		Count:                types.Int64Value(2),
+		Reason:               types.StringValue("command"),
+		Context:              types.StringValue("toplevel"),
+		Object:               types.StringValue("get"),
+		Username:             types.StringValue("app"),
+		ClientInfo:           types.StringValue("id=5 addr=127.0.0.1:50000 user=app"),
+		AgeSeconds:           types.Float64Value(1.5),
+		TimestampCreated:     types.Int64Value(1700000000000),
+		TimestampLastUpdated: types.Int64Value(1700000001000),
+	}
+
+	tests := []struct {
+		name string
+		raw  interface{}
+	}{
+		{
+			name: "RESP2",
+			raw: []interface{}{
+				"count", int64(2),
+				"reason", "command",
+				"context", "toplevel",
+				"object", "get",
+				"username", "app",
+				"age-seconds", "1.5",
+				"client-info", "id=5 addr=127.0.0.1:50000 user=app",
+				"entry-id", int64(3),
+				"timestamp-created", int64(1700000000000),
+				"timestamp-last-updated", int64(1700000001000),
+			},
+		},
+		{
+			name: "RESP3",
+			raw: map[interface{}]interface{}{
+				"count":                  int64(2),
+				"reason":                 "command",
+				"context":                "toplevel",
+				"object":                 "get",
+				"username":               "app",
+				"age-seconds":            float64(1.5),
+				"client-info":            "id=5 addr=127.0.0.1:50000 user=app",
+				"entry-id":               int64(3),
+				"timestamp-created":      int64(1700000000000),
+				"timestamp-last-updated": int64(1700000001000),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := parseACLLogEntry(tt.raw)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, entry)
+		})
+	}
+
+	_, err := parseACLLogEntry("unexpected")
+	assert.Error(t, err)
+
+	_, err = parseACLLogEntry([]interface{}{"count", "many"})
+	assert.Error(t, err)
+}
+
+func TestACLLogEntryMatches(t *testing.T) {
+	entry := &ACLLogEntryModel{
+		Reason:               types.StringValue("auth"),
+		Username:             types.StringValue("app"),
+		TimestampLastUpdated: types.Int64Value(2000),
+	}
+
+	filters := func(username, reason string, minTimestamp int64) *ACLLogDataSourceModel {
+		data := &ACLLogDataSourceModel{
+			Username:     types.StringNull(),
+			Reason:       types.StringNull(),
+			MinTimestamp: types.Int64Null(),
+		}
+		if username != "" {
+			data.Username = types.StringValue(username)
+		}
+		if reason != "" {
+			data.Reason = types.StringValue(reason)
+		}
+		if minTimestamp != 0 {
+			data.MinTimestamp = types.Int64Value(minTimestamp)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		data     *ACLLogDataSourceModel
+		expected bool
+	}{
+		{name: "no filters", data: filters("", "", 0), expected: true},
+		{name: "matching username", data: filters("app", "", 0), expected: true},
+		{name: "other username", data: filters("other", "", 0), expected: false},
+		{name: "matching reason", data: filters("", "auth", 0), expected: true},
+		{name: "other reason", data: filters("", "key", 0), expected: false},
+		{name: "at min timestamp", data: filters("", "", 2000), expected: true},
+		{name: "before min timestamp", data: filters("", "", 2001), expected: false},
+		{name: "all filters", data: filters("app", "auth", 1000), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, aclLogEntryMatches(tt.data, entry))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CommandCategoriesDataSource{}
+
+func NewCommandCategoriesDataSource() datasource.DataSource {
+	return &CommandCategoriesDataSource{}
+}
+
+// CommandCategoriesDataSource defines the data source implementation.
+type CommandCategoriesDataSource struct {
+	redisClient *RedisClient
+}
+
+// CommandCategoriesDataSourceModel describes the data source data model.
+type CommandCategoriesDataSourceModel struct {
+	Names      types.Set `tfsdk:"names"`
+	Categories types.Map `tfsdk:"categories"`
+}
+
+func (d *CommandCategoriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_command_categories"
+}
+
+func (d *CommandCategoriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets the ACL command categories of the connected server and the commands in each of them, using `ACL CAT`.",
+
+		Attributes: map[string]schema.Attribute{
+			"names": schema.SetAttribute{
+				MarkdownDescription: "The categories to return, without the `@` prefix. Defaults to every category the server knows.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(categoryNameRegexp, "must be a category name without the @ prefix"),
+					),
+				},
+			},
+			"categories": schema.MapAttribute{
+				MarkdownDescription: "The commands of each category, keyed by category name and sorted. Subcommands are listed as `command|subcommand`.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CommandCategoriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.redisClient = redisClient
+}
+
+func (d *CommandCategoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CommandCategoriesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.redisClient.mutex.Lock()
+	defer d.redisClient.mutex.Unlock()
+
+	names := sortedStrings(data.Names)
+	if data.Names.IsNull() {
+		var err error
+		names, err = d.redisClient.commandCategories(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list command categories, got error: %s", err))
+			return
+		}
+	}
+
+	categories := make(map[string]attr.Value, len(names))
+	for _, name := range names {
+		commands, err := d.redisClient.client.Do(ctx, "ACL", "CAT", name).StringSlice()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list the commands of category %s, got error: %s", name, err))
+			return
+		}
+		sort.Strings(commands)
+
+		elements := make([]attr.Value, 0, len(commands))
+		for _, command := range commands {
+			elements = append(elements, types.StringValue(command))
+		}
+		categories[name] = types.ListValueMust(types.StringType, elements)
+	}
+	data.Categories = types.MapValueMust(types.ListType{ElemType: types.StringType}, categories)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// commandCategories returns the ACL categories the server knows, sorted.
+func (c *RedisClient) commandCategories(ctx context.Context) ([]string, error) {
+	categories, err := c.client.Do(ctx, "ACL", "CAT").StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCommandCategoriesDataSource_All(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandCategoriesDataSourceConfigAll(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.redisacl_command_categories.test", "categories.%", func(value string) error {
+						if value == "0" {
+							return fmt.Errorf("Expected at least 1 category, got 0")
+						}
+						return nil
+					}),
+					resource.TestCheckTypeSetElemAttr("data.redisacl_command_categories.test", "categories.keyspace.*", "del"),
+					resource.TestCheckTypeSetElemAttr("data.redisacl_command_categories.test", "categories.read.*", "get"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCommandCategoriesDataSource_Names(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandCategoriesDataSourceConfigNames(`["dangerous", "slow"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redisacl_command_categories.test", "categories.%", "2"),
+					resource.TestCheckTypeSetElemAttr("data.redisacl_command_categories.test", "categories.dangerous.*", "flushall"),
+					resource.TestCheckTypeSetElemAttr("data.redisacl_command_categories.test", "categories.slow.*", "keys"),
+				),
+			},
+			{
+				Config:      testAccCommandCategoriesDataSourceConfigNames(`["@dangerous"]`),
+				ExpectError: regexp.MustCompile(`must be a category name without the @ prefix`),
+			},
+			{
+				Config:      testAccCommandCategoriesDataSourceConfigNames(`["reed"]`),
+				ExpectError: regexp.MustCompile(`Unable to list the commands of category reed`),
+			},
+		},
+	})
+}
+
+func testAccCommandCategoriesDataSourceConfigAll() string {
+	return `
+provider "redisacl" {}
+
+data "redisacl_command_categories" "test" {}
+`
+}
+
+func testAccCommandCategoriesDataSourceConfigNames(names string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+data "redisacl_command_categories" "test" {
+  names = %s
+}
+`, names)
+}
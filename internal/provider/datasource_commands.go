@@ -0,0 +1,475 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CommandsDataSource{}
+
+func NewCommandsDataSource() datasource.DataSource {
+	return &CommandsDataSource{}
+}
+
+// CommandsDataSource defines the data source implementation.
+type CommandsDataSource struct {
+	redisClient *RedisClient
+}
+
+// CommandsDataSourceModel describes the data source data model.
+type CommandsDataSourceModel struct {
+	Category types.String   `tfsdk:"category"`
+	Module   types.String   `tfsdk:"module"`
+	Flag     types.String   `tfsdk:"flag"`
+	HasKeys  types.Bool     `tfsdk:"has_keys"`
+	Commands []CommandModel `tfsdk:"commands"`
+}
+
+// CommandModel describes a command or subcommand reported by COMMAND INFO.
+type CommandModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Arity         types.Int64    `tfsdk:"arity"`
+	Flags         types.List     `tfsdk:"flags"`
+	ACLCategories types.List     `tfsdk:"acl_categories"`
+	FirstKey      types.Int64    `tfsdk:"first_key"`
+	LastKey       types.Int64    `tfsdk:"last_key"`
+	KeyStep       types.Int64    `tfsdk:"key_step"`
+	KeySpecs      []KeySpecModel `tfsdk:"key_specs"`
+	Subcommands   types.List     `tfsdk:"subcommands"`
+	Summary       types.String   `tfsdk:"summary"`
+	Group         types.String   `tfsdk:"group"`
+	Module        types.String   `tfsdk:"module"`
+}
+
+// KeySpecModel describes a key specification of a command.
+type KeySpecModel struct {
+	Flags           types.List   `tfsdk:"flags"`
+	BeginSearchType types.String `tfsdk:"begin_search_type"`
+	FindKeysType    types.String `tfsdk:"find_keys_type"`
+}
+
+func (d *CommandsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_commands"
+}
+
+func (d *CommandsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets the commands of the connected server with their ACL categories, flags and key specifications, " +
+			"using `COMMAND INFO` and `COMMAND DOCS`. Subcommands are returned as separate entries named `command|subcommand`, " +
+			"so every name can be used in a `+` or `-` rule.",
+
+		Attributes: map[string]schema.Attribute{
+			"category": schema.StringAttribute{
+				MarkdownDescription: "Only return commands in this ACL category, without the `@` prefix.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(categoryNameRegexp, "must be a category name without the @ prefix"),
+				},
+			},
+			"module": schema.StringAttribute{
+				MarkdownDescription: "Only return commands provided by this module.",
+				Optional:            true,
+			},
+			"flag": schema.StringAttribute{
+				MarkdownDescription: "Only return commands with this flag, such as `readonly`, `write` or `admin`.",
+				Optional:            true,
+			},
+			"has_keys": schema.BoolAttribute{
+				MarkdownDescription: "Only return commands that take key arguments (`true`) or that do not (`false`).",
+				Optional:            true,
+			},
+			"commands": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching commands, sorted by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the command, or `command|subcommand` for a subcommand.",
+							Computed:            true,
+						},
+						"arity": schema.Int64Attribute{
+							MarkdownDescription: "The number of arguments, negative when it is a minimum.",
+							Computed:            true,
+						},
+						"flags": schema.ListAttribute{
+							MarkdownDescription: "The command flags, such as `readonly` or `write`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"acl_categories": schema.ListAttribute{
+							MarkdownDescription: "The ACL categories of the command, without the `@` prefix.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"first_key": schema.Int64Attribute{
+							MarkdownDescription: "The position of the first key argument, 0 when there is none.",
+							Computed:            true,
+						},
+						"last_key": schema.Int64Attribute{
+							MarkdownDescription: "The position of the last key argument, negative when counted from the end.",
+							Computed:            true,
+						},
+						"key_step": schema.Int64Attribute{
+							MarkdownDescription: "The step between key arguments.",
+							Computed:            true,
+						},
+						"key_specs": schema.ListNestedAttribute{
+							MarkdownDescription: "The key specifications of the command (Redis 7.0 or later).",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"flags": schema.ListAttribute{
+										MarkdownDescription: "The key flags, such as `RO`, `RW` or `access`.",
+										ElementType:         types.StringType,
+										Computed:            true,
+									},
+									"begin_search_type": schema.StringAttribute{
+										MarkdownDescription: "How the first key is found: `index`, `keyword` or `unknown`.",
+										Computed:            true,
+									},
+									"find_keys_type": schema.StringAttribute{
+										MarkdownDescription: "How the following keys are found: `range`, `keynum` or `unknown`.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"subcommands": schema.ListAttribute{
+							MarkdownDescription: "The names of the subcommands of the command.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"summary": schema.StringAttribute{
+							MarkdownDescription: "A short description of the command (Redis 7.0 or later).",
+							Computed:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "The functional group of the command, such as `string` or `server` (Redis 7.0 or later).",
+							Computed:            true,
+						},
+						"module": schema.StringAttribute{
+							MarkdownDescription: "The module that provides the command, null for built-in commands (Redis 7.0 or later).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CommandsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.redisClient = redisClient
+}
+
+func (d *CommandsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CommandsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.redisClient.mutex.Lock()
+	defer d.redisClient.mutex.Unlock()
+
+	info, err := d.redisClient.client.Do(ctx, "COMMAND").Slice()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get command info, got error: %s", err))
+		return
+	}
+	commands, err := parseCommandInfo(info)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse command info, got error: %s", err))
+		return
+	}
+
+	// COMMAND DOCS only exists since Redis 7.0; older servers simply leave
+	// the documentation attributes null.
+	docs, err := d.redisClient.client.Do(ctx, "COMMAND", "DOCS").Result()
+	if err == nil {
+		err = applyCommandDocs(commands, docs, "")
+	}
+	if err != nil && !isUnknownSubcommand(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get command docs, got error: %s", err))
+		return
+	}
+
+	data.Commands = []CommandModel{}
+	for _, command := range commands {
+		if commandMatches(&data, command) {
+			data.Commands = append(data.Commands, *command)
+		}
+	}
+	sort.Slice(data.Commands, func(i, j int) bool {
+		return data.Commands[i].Name.ValueString() < data.Commands[j].Name.ValueString()
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// commandMatches reports whether command passes the filters of data.
+func commandMatches(data *CommandsDataSourceModel, command *CommandModel) bool {
+	if !data.Category.IsNull() && !listContains(command.ACLCategories, data.Category.ValueString()) {
+		return false
+	}
+	if !data.Module.IsNull() && command.Module.ValueString() != data.Module.ValueString() {
+		return false
+	}
+	if !data.Flag.IsNull() && !listContains(command.Flags, data.Flag.ValueString()) {
+		return false
+	}
+	if !data.HasKeys.IsNull() {
+		hasKeys := command.FirstKey.ValueInt64() > 0 || len(command.KeySpecs) > 0
+		if hasKeys != data.HasKeys.ValueBool() {
+			return false
+		}
+	}
+	return true
+}
+
+func listContains(list types.List, value string) bool {
+	for _, element := range list.Elements() {
+		if element.(types.String).ValueString() == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCommandInfo parses the reply of COMMAND or COMMAND INFO into a map of commands
+// keyed by name, subcommands included.
+func parseCommandInfo(info []interface{}) (map[string]*CommandModel, error) {
+	commands := map[string]*CommandModel{}
+	for _, raw := range info {
+		// Unknown commands are reported as nil
+		if raw == nil {
+			continue
+		}
+		if err := parseCommandInfoEntry(raw, commands); err != nil {
+			return nil, err
+		}
+	}
+	return commands, nil
+}
+
+// parseCommandInfoEntry parses a single COMMAND INFO entry:
+// name, arity, flags, first key, last key, step, ACL categories (Redis 6.0),
+// then tips, key specs and subcommands (Redis 7.0).
+func parseCommandInfoEntry(raw interface{}, commands map[string]*CommandModel) error {
+	fields, ok := raw.([]interface{})
+	if !ok || len(fields) < 6 {
+		return fmt.Errorf("unexpected COMMAND INFO entry %v", raw)
+	}
+
+	name, ok := fields[0].(string)
+	if !ok {
+		return fmt.Errorf("COMMAND INFO name is not a string")
+	}
+	command := &CommandModel{
+		Name:          types.StringValue(strings.ToLower(name)),
+		ACLCategories: types.ListValueMust(types.StringType, []attr.Value{}),
+		KeySpecs:      []KeySpecModel{},
+		Subcommands:   types.ListValueMust(types.StringType, []attr.Value{}),
+		Summary:       types.StringNull(),
+		Group:         types.StringNull(),
+		Module:        types.StringNull(),
+	}
+
+	var err error
+	if command.Arity, err = logInt64(fields[1]); err != nil {
+		return fmt.Errorf("invalid arity of command %s: %w", name, err)
+	}
+	if command.FirstKey, err = logInt64(fields[3]); err != nil {
+		return fmt.Errorf("invalid first key of command %s: %w", name, err)
+	}
+	if command.LastKey, err = logInt64(fields[4]); err != nil {
+		return fmt.Errorf("invalid last key of command %s: %w", name, err)
+	}
+	if command.KeyStep, err = logInt64(fields[5]); err != nil {
+		return fmt.Errorf("invalid key step of command %s: %w", name, err)
+	}
+	command.Flags = stringList(fields[2], "")
+
+	if len(fields) > 6 {
+		command.ACLCategories = stringList(fields[6], "@")
+	}
+	if len(fields) > 8 {
+		specs, _ := fields[8].([]interface{})
+		for _, spec := range specs {
+			keySpec, err := parseKeySpec(spec)
+			if err != nil {
+				return fmt.Errorf("invalid key spec of command %s: %w", name, err)
+			}
+			command.KeySpecs = append(command.KeySpecs, keySpec)
+		}
+	}
+	if len(fields) > 9 {
+		subcommands, _ := fields[9].([]interface{})
+		var names []attr.Value
+		for _, subcommand := range subcommands {
+			if err := parseCommandInfoEntry(subcommand, commands); err != nil {
+				return err
+			}
+			names = append(names, types.StringValue(strings.ToLower(subcommand.([]interface{})[0].(string))))
+		}
+		if len(names) > 0 {
+			command.Subcommands = types.ListValueMust(types.StringType, names)
+		}
+	}
+
+	commands[command.Name.ValueString()] = command
+	return nil
+}
+
+// parseKeySpec parses a key specification of COMMAND INFO.
+func parseKeySpec(raw interface{}) (KeySpecModel, error) {
+	fields, err := fieldMap(raw)
+	if err != nil {
+		return KeySpecModel{}, err
+	}
+
+	keySpec := KeySpecModel{
+		Flags:           stringList(fields["flags"], ""),
+		BeginSearchType: types.StringNull(),
+		FindKeysType:    types.StringNull(),
+	}
+	if beginSearch, err := fieldMap(fields["begin_search"]); err == nil {
+		if searchType, ok := beginSearch["type"].(string); ok {
+			keySpec.BeginSearchType = types.StringValue(searchType)
+		}
+	}
+	if findKeys, err := fieldMap(fields["find_keys"]); err == nil {
+		if findType, ok := findKeys["type"].(string); ok {
+			keySpec.FindKeysType = types.StringValue(findType)
+		}
+	}
+	return keySpec, nil
+}
+
+// applyCommandDocs copies the summary, group and module of the reply of
+// COMMAND DOCS to the matching commands. prefix is the name of the parent
+// command when applying the docs of its subcommands.
+func applyCommandDocs(commands map[string]*CommandModel, raw interface{}, prefix string) error {
+	docs, err := fieldMap(raw)
+	if err != nil {
+		return err
+	}
+
+	for name, rawDoc := range docs {
+		doc, err := fieldMap(rawDoc)
+		if err != nil {
+			return fmt.Errorf("invalid docs of command %s: %w", name, err)
+		}
+		name = strings.ToLower(name)
+		if prefix != "" && !strings.Contains(name, "|") {
+			name = prefix + "|" + name
+		}
+
+		if command, ok := commands[name]; ok {
+			if summary, ok := doc["summary"].(string); ok {
+				command.Summary = types.StringValue(summary)
+			}
+			if group, ok := doc["group"].(string); ok {
+				command.Group = types.StringValue(group)
+			}
+			if module, ok := doc["module"].(string); ok {
+				command.Module = types.StringValue(module)
+			}
+		}
+		if subcommands, ok := doc["subcommands"]; ok {
+			if err := applyCommandDocs(commands, subcommands, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if prefix != "" {
+		return nil
+	}
+
+	// Subcommands of module commands come from the same module
+	for _, command := range commands {
+		parent, _, ok := strings.Cut(command.Name.ValueString(), "|")
+		if ok && command.Module.IsNull() && commands[parent] != nil {
+			command.Module = commands[parent].Module
+		}
+	}
+	return nil
+}
+
+// fieldMap converts a reply that is a flat list of field names and values
+// with RESP2 and a map with RESP3 to a map.
+func fieldMap(raw interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	switch value := raw.(type) {
+	case []interface{}:
+		for i := 0; i+1 < len(value); i += 2 {
+			key, ok := value[i].(string)
+			if !ok {
+				return nil, fmt.Errorf("field name is not a string")
+			}
+			fields[key] = value[i+1]
+		}
+	case map[interface{}]interface{}:
+		for k, v := range value {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("field name is not a string")
+			}
+			fields[key] = v
+		}
+	default:
+		return nil, fmt.Errorf("unexpected reply type %T", raw)
+	}
+	return fields, nil
+}
+
+// stringList converts a reply that is a list of strings to a list value,
+// trimming prefix from each element.
+func stringList(raw interface{}, prefix string) types.List {
+	values, _ := raw.([]interface{})
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			elements = append(elements, types.StringValue(strings.TrimPrefix(s, prefix)))
+		}
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// isUnknownSubcommand reports whether err is the reply of a server that
+// does not know a subcommand.
+func isUnknownSubcommand(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "unknown subcommand")
+}
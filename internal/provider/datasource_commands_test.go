@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccCommandsDataSource_ReadOnlyWithKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandsDataSourceConfig("read", "readonly", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.redisacl_commands.test", "commands.*", map[string]string{
+						"name":      "get",
+						"arity":     "2",
+						"first_key": "1",
+						"group":     "string",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.redisacl_commands.test", "commands.*", map[string]string{
+						"name": "hget",
+					}),
+					testAccCheckCommandsExclude("data.redisacl_commands.test", []string{"set", "ping", "dbsize"}),
+				),
+			},
+			{
+				Config: testAccCommandsDataSourceConfig("admin", "", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.redisacl_commands.test", "commands.*", map[string]string{
+						"name": "config|set",
+					}),
+					testAccCheckCommandsExclude("data.redisacl_commands.test", []string{"get"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCommandsExclude(resourceName string, excluded []string) resource.TestCheckFunc {
+	var checks []resource.TestCheckFunc
+	for _, name := range excluded {
+		checks = append(checks, func(s *terraform.State) error {
+			rs, ok := s.RootModule().Resources[resourceName]
+			if !ok {
+				return fmt.Errorf("Not found: %s", resourceName)
+			}
+			for key, value := range rs.Primary.Attributes {
+				if value == name && strings.HasSuffix(key, ".name") {
+					return fmt.Errorf("Expected command %s to be filtered out", name)
+				}
+			}
+			return nil
+		})
+	}
+	return resource.ComposeAggregateTestCheckFunc(checks...)
+}
+
+func testAccCommandsDataSourceConfig(category, flag string, hasKeys bool) string {
+	flagLine := ""
+	if flag != "" {
+		flagLine = fmt.Sprintf("flag     = %q", flag)
+	}
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+data "redisacl_commands" "test" {
+  category = %[1]q
+  %[2]s
+  has_keys = %[3]t
+}
+`, category, flagLine, hasKeys)
+}
+
+func TestParseCommandInfo(t *testing.T) {
+	strs := func(values ...string) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	info := []interface{}{
+		// Redis 7 reply with a RESP2 key spec
+		[]interface{}{
+			"get", int64(2), []interface{}{"readonly", "fast"}, int64(1), int64(1), int64(1),
+			[]interface{}{"@read", "@string", "@fast"},
+			[]interface{}{},
+			[]interface{}{
+				[]interface{}{
+					"flags", []interface{}{"RO", "access"},
+					"begin_search", []interface{}{"type", "index", "spec", []interface{}{"index", int64(1)}},
+					"find_keys", []interface{}{"type", "range", "spec", []interface{}{"lastkey", int64(0), "keystep", int64(1), "limit", int64(0)}},
+				},
+			},
+			[]interface{}{},
+		},
+		// Redis 7 reply with a subcommand and a RESP3 key spec
+		[]interface{}{
+			"object", int64(-2), []interface{}{}, int64(0), int64(0), int64(0),
+			[]interface{}{"@slow"},
+			[]interface{}{},
+			[]interface{}{},
+			[]interface{}{
+				[]interface{}{
+					"object|encoding", int64(3), []interface{}{"readonly"}, int64(2), int64(2), int64(1),
+					[]interface{}{"@keyspace", "@read", "@slow"},
+					[]interface{}{},
+					[]interface{}{
+						map[interface{}]interface{}{
+							"flags":        []interface{}{"RO"},
+							"begin_search": map[interface{}]interface{}{"type": "index"},
+							"find_keys":    map[interface{}]interface{}{"type": "range"},
+						},
+					},
+					[]interface{}{},
+				},
+			},
+		},
+		// Redis 6.0 reply without key specs
+		[]interface{}{"PING", int64(-1), []interface{}{"stale", "fast"}, int64(0), int64(0), int64(0), []interface{}{"@connection"}},
+		// Unknown commands are nil
+		nil,
+	}
+
+	commands, err := parseCommandInfo(info)
+	assert.NoError(t, err)
+	assert.Len(t, commands, 4)
+
+	get := commands["get"]
+	assert.Equal(t, types.Int64Value(2), get.Arity)
+	assert.Equal(t, strs("readonly", "fast"), get.Flags)
+	assert.Equal(t, strs("read", "string", "fast"), get.ACLCategories)
+	assert.Equal(t, []KeySpecModel{{
+		Flags:           strs("RO", "access"),
+		BeginSearchType: types.StringValue("index"),
+		FindKeysType:    types.StringValue("range"),
+	}}, get.KeySpecs)
+
+	object := commands["object"]
+	assert.Equal(t, strs("object|encoding"), object.Subcommands)
+	assert.Empty(t, object.KeySpecs)
+
+	encoding := commands["object|encoding"]
+	assert.Equal(t, strs("keyspace", "read", "slow"), encoding.ACLCategories)
+	assert.Equal(t, types.StringValue("index"), encoding.KeySpecs[0].BeginSearchType)
+
+	ping := commands["ping"]
+	assert.Equal(t, strs("connection"), ping.ACLCategories)
+	assert.Empty(t, ping.KeySpecs)
+	assert.Equal(t, strs(), ping.Subcommands)
+
+	_, err = parseCommandInfo([]interface{}{[]interface{}{"get", "two"}})
+	assert.Error(t, err)
+}
+
+func TestApplyCommandDocs(t *testing.T) {
+	commands, err := parseCommandInfo([]interface{}{
+		[]interface{}{"get", int64(2), []interface{}{}, int64(1), int64(1), int64(1), []interface{}{}},
+		[]interface{}{"json.get", int64(-2), []interface{}{}, int64(1), int64(1), int64(1), []interface{}{},
+			[]interface{}{}, []interface{}{},
+			[]interface{}{
+				[]interface{}{"json.get|sub", int64(2), []interface{}{}, int64(0), int64(0), int64(0), []interface{}{}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	docs := map[interface{}]interface{}{
+		"get": []interface{}{"summary", "Returns the string value of a key.", "since", "1.0.0", "group", "string"},
+		"json.get": map[interface{}]interface{}{
+			"summary": "Gets the value at one or more paths.",
+			"group":   "module",
+			"module":  "ReJSON",
+			"subcommands": map[interface{}]interface{}{
+				"json.get|sub": map[interface{}]interface{}{"summary": "A subcommand."},
+			},
+		},
+		"unknown": []interface{}{"summary", "Not in COMMAND INFO."},
+	}
+
+	assert.NoError(t, applyCommandDocs(commands, docs, ""))
+	assert.Equal(t, types.StringValue("Returns the string value of a key."), commands["get"].Summary)
+	assert.Equal(t, types.StringValue("string"), commands["get"].Group)
+	assert.True(t, commands["get"].Module.IsNull())
+	assert.Equal(t, types.StringValue("ReJSON"), commands["json.get"].Module)
+	assert.Equal(t, types.StringValue("A subcommand."), commands["json.get|sub"].Summary)
+	assert.Equal(t, types.StringValue("ReJSON"), commands["json.get|sub"].Module)
+}
+
+func TestCommandMatches(t *testing.T) {
+	strs := func(values ...string) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	get := &CommandModel{
+		Flags:         strs("readonly", "fast"),
+		ACLCategories: strs("read", "string"),
+		FirstKey:      types.Int64Value(1),
+		Module:        types.StringNull(),
+	}
+	jsonGet := &CommandModel{
+		Flags:         strs("readonly"),
+		ACLCategories: strs("read", "json"),
+		FirstKey:      types.Int64Value(0),
+		KeySpecs:      []KeySpecModel{{}},
+		Module:        types.StringValue("ReJSON"),
+	}
+	ping := &CommandModel{
+		Flags:         strs("fast"),
+		ACLCategories: strs("connection"),
+		FirstKey:      types.Int64Value(0),
+		Module:        types.StringNull(),
+	}
+
+	filters := func(category, module, flag string, hasKeys *bool) *CommandsDataSourceModel {
+		data := &CommandsDataSourceModel{
+			Category: types.StringNull(),
+			Module:   types.StringNull(),
+			Flag:     types.StringNull(),
+			HasKeys:  types.BoolNull(),
+		}
+		if category != "" {
+			data.Category = types.StringValue(category)
+		}
+		if module != "" {
+			data.Module = types.StringValue(module)
+		}
+		if flag != "" {
+			data.Flag = types.StringValue(flag)
+		}
+		if hasKeys != nil {
+			data.HasKeys = types.BoolValue(*hasKeys)
+		}
+		return data
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name     string
+		data     *CommandsDataSourceModel
+		expected []bool
+	}{
+		{name: "no filters", data: filters("", "", "", nil), expected: []bool{true, true, true}},
+		{name: "category", data: filters("read", "", "", nil), expected: []bool{true, true, false}},
+		{name: "module", data: filters("", "ReJSON", "", nil), expected: []bool{false, true, false}},
+		{name: "flag", data: filters("", "", "fast", nil), expected: []bool{true, false, true}},
+		{name: "with keys", data: filters("", "", "", &yes), expected: []bool{true, true, false}},
+		{name: "without keys", data: filters("", "", "", &no), expected: []bool{false, false, true}},
+		{name: "read-only with keys", data: filters("read", "", "readonly", &yes), expected: []bool{true, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, []bool{
+				commandMatches(tt.data, get),
+				commandMatches(tt.data, jsonGet),
+				commandMatches(tt.data, ping),
+			})
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerDataSource{}
+
+func NewServerDataSource() datasource.DataSource {
+	return &ServerDataSource{}
+}
+
+// ServerDataSource defines the data source implementation.
+type ServerDataSource struct {
+	redisClient *RedisClient
+}
+
+// ServerDataSourceModel describes the data source data model.
+type ServerDataSourceModel struct {
+	Flavor            types.String `tfsdk:"flavor"`
+	Version           types.String `tfsdk:"version"`
+	Mode              types.String `tfsdk:"mode"`
+	ACLFileConfigured types.Bool   `tfsdk:"aclfile_configured"`
+	ACLPubsubDefault  types.String `tfsdk:"acl_pubsub_default"`
+	ACLLogMaxLen      types.Int64  `tfsdk:"acllog_max_len"`
+	Modules           types.List   `tfsdk:"modules"`
+	CurrentUser       types.String `tfsdk:"current_user"`
+}
+
+func (d *ServerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+func (d *ServerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about the server the provider is connected to: its flavor, version and run mode, its ACL configuration, its loaded modules and the user the provider is authenticated as. In cluster and sentinel topologies the configuration is read from the node the provider's connection is routed to.",
+
+		Attributes: map[string]schema.Attribute{
+			"flavor": schema.StringAttribute{
+				MarkdownDescription: "The server flavor: `redis`, `valkey`, `keydb` or `dragonfly`.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The server version, such as `7.2.4`.",
+				Computed:            true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How the provider reaches the server: `standalone`, `cluster` or `sentinel`.",
+				Computed:            true,
+			},
+			"aclfile_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the server loads its users from an `aclfile`.",
+				Computed:            true,
+			},
+			"acl_pubsub_default": schema.StringAttribute{
+				MarkdownDescription: "The `acl-pubsub-default` setting: `resetchannels` or `allchannels`. Null when the server does not have the setting (before Redis 6.2).",
+				Computed:            true,
+			},
+			"acllog_max_len": schema.Int64Attribute{
+				MarkdownDescription: "The `acllog-max-len` setting: the maximum number of entries in the ACL log. Null when the server does not have the setting.",
+				Computed:            true,
+			},
+			"modules": schema.ListAttribute{
+				MarkdownDescription: "The names of the modules loaded with `MODULE LIST`, sorted. Empty when the server does not support modules.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"current_user": schema.StringAttribute{
+				MarkdownDescription: "The user the provider is authenticated as, from `ACL WHOAMI`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ServerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.redisClient = redisClient
+}
+
+func (d *ServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.redisClient.mutex.Lock()
+	defer d.redisClient.mutex.Unlock()
+
+	client := d.redisClient.client
+
+	// The server is detected when the provider is configured, unless it
+	// failed then
+	server := d.redisClient.server
+	if server == nil {
+		var err error
+		server, err = detectServer(ctx, client, &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detect the server flavor and version, got error: %s", err))
+			return
+		}
+	}
+	data.Flavor = types.StringValue(server.flavor)
+	data.Version = types.StringValue(server.version)
+
+	data.Mode = types.StringValue(server.mode)
+	switch {
+	case d.redisClient.sentinel != nil:
+		data.Mode = types.StringValue("sentinel")
+	case isClusterClient(client):
+		data.Mode = types.StringValue("cluster")
+	}
+
+	config, err := client.ConfigGet(ctx, "acl*").Result()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the ACL configuration, got error: %s", err))
+		return
+	}
+	data.ACLFileConfigured = types.BoolValue(config["aclfile"] != "")
+	data.ACLPubsubDefault = types.StringNull()
+	if value, ok := config["acl-pubsub-default"]; ok {
+		data.ACLPubsubDefault = types.StringValue(value)
+	}
+	data.ACLLogMaxLen = types.Int64Null()
+	if value, ok := config["acllog-max-len"]; ok {
+		maxLen, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse acllog-max-len %q, got error: %s", value, err))
+			return
+		}
+		data.ACLLogMaxLen = types.Int64Value(maxLen)
+	}
+
+	var modules []string
+	list, err := client.Do(ctx, "MODULE", "LIST").Slice()
+	switch {
+	case isUnknownCommand(err):
+		// Servers without module support, such as Dragonfly
+	case err != nil:
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list modules, got error: %s", err))
+		return
+	default:
+		modules, err = parseModuleList(list)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse MODULE LIST, got error: %s", err))
+			return
+		}
+	}
+	elements := make([]attr.Value, 0, len(modules))
+	for _, module := range modules {
+		elements = append(elements, types.StringValue(module))
+	}
+	data.Modules = types.ListValueMust(types.StringType, elements)
+
+	currentUser, err := client.Do(ctx, "ACL", "WHOAMI").Text()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the authenticated user, got error: %s", err))
+		return
+	}
+	data.CurrentUser = types.StringValue(currentUser)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// isClusterClient tells whether client connects to a Redis Cluster.
+func isClusterClient(client redis.UniversalClient) bool {
+	_, ok := client.(*redis.ClusterClient)
+	return ok
+}
+
+// isUnknownCommand tells whether err is the error a server returns for a
+// command it does not implement.
+func isUnknownCommand(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "unknown command")
+}
+
+// parseModuleList returns the sorted module names of a MODULE LIST reply, in
+// which every module is a RESP2 array or RESP3 map of fields.
+func parseModuleList(list []interface{}) ([]string, error) {
+	names := make([]string, 0, len(list))
+	for _, raw := range list {
+		fields, err := fieldMap(raw)
+		if err != nil {
+			return nil, err
+		}
+		name, ok := fields["name"].(string)
+		if !ok {
+			return nil, fmt.Errorf("module without a name")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccServerDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServerDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "flavor", "redis"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "version", "7.4.7"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "mode", "standalone"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "aclfile_configured", "false"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "acl_pubsub_default", "resetchannels"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "acllog_max_len", "128"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "modules.#", "0"),
+					resource.TestCheckResourceAttr("data.redisacl_server.test", "current_user", "default"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServerDataSourceConfig() string {
+	return `
+provider "redisacl" {}
+
+data "redisacl_server" "test" {}
+`
+}
+
+func TestParseModuleList(t *testing.T) {
+	modules, err := parseModuleList([]interface{}{
+		// RESP2 reply
+		[]interface{}{"name", "search", "ver", int64(21005), "path", "/opt/redis-stack/lib/redisearch.so", "args", []interface{}{}},
+		// RESP3 reply
+		map[interface{}]interface{}{"name": "ReJSON", "ver": int64(20609)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ReJSON", "search"}, modules)
+
+	modules, err = parseModuleList([]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, modules)
+
+	_, err = parseModuleList([]interface{}{[]interface{}{"ver", int64(1)}})
+	assert.Error(t, err)
+}
+
+func TestIsUnknownCommand(t *testing.T) {
+	assert.True(t, isUnknownCommand(errors.New("ERR unknown command 'MODULE', with args beginning with: 'LIST' ")))
+	assert.False(t, isUnknownCommand(errors.New("ERR unknown subcommand 'LIST'")))
+	assert.False(t, isUnknownCommand(nil))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultPasswordBits matches the default of ACL GENPASS.
+const defaultPasswordBits = 256
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &PasswordEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &PasswordEphemeralResource{}
+
+func NewPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &PasswordEphemeralResource{}
+}
+
+// PasswordEphemeralResource defines the ephemeral resource implementation.
+type PasswordEphemeralResource struct {
+	redisClient *RedisClient
+}
+
+// PasswordEphemeralResourceModel describes the ephemeral resource data model.
+type PasswordEphemeralResourceModel struct {
+	Bits    types.Int64  `tfsdk:"bits"`
+	Offline types.Bool   `tfsdk:"offline"`
+	Value   types.String `tfsdk:"value"`
+}
+
+func (e *PasswordEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password"
+}
+
+func (e *PasswordEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a random password with `ACL GENPASS` without storing it in the Terraform state. Pass the value to the write-only `passwords_wo` attribute of `redisacl_user`.",
+
+		Attributes: map[string]schema.Attribute{
+			"bits": schema.Int64Attribute{
+				MarkdownDescription: "The number of random bits in the password (defaults to 256). The password has one hex character per 4 bits.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4096),
+				},
+			},
+			"offline": schema.BoolAttribute{
+				MarkdownDescription: "Generate the password locally with a cryptographically secure random source instead of calling `ACL GENPASS`.",
+				Optional:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The generated password.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *PasswordEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.redisClient = redisClient
+}
+
+func (e *PasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data PasswordEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bits := int64(defaultPasswordBits)
+	if !data.Bits.IsNull() {
+		bits = data.Bits.ValueInt64()
+	}
+
+	var password string
+	if !data.Offline.ValueBool() && e.redisClient != nil {
+		value, err := e.redisClient.client.Do(ctx, "ACL", "GENPASS", bits).Text()
+		if err == nil {
+			password = value
+		} else {
+			resp.Diagnostics.AddWarning(
+				"ACL GENPASS Failed",
+				fmt.Sprintf("Unable to generate a password with ACL GENPASS, generating it locally instead. Got error: %s", err),
+			)
+		}
+	}
+
+	if password == "" {
+		value, err := generatePassword(bits)
+		if err != nil {
+			resp.Diagnostics.AddError("Password Generation Error", fmt.Sprintf("Unable to generate password, got error: %s", err))
+			return
+		}
+		password = value
+	}
+
+	data.Value = types.StringValue(password)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// generatePassword mirrors ACL GENPASS locally: it reads the requested number
+// of bits from crypto/rand and returns them as a hex string with one
+// character per 4 bits, rounded up.
+func generatePassword(bits int64) (string, error) {
+	buf := make([]byte, (bits+7)/8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf)[:(bits+3)/4], nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		bits     int64
+		expected int
+	}{
+		{bits: 1, expected: 1},
+		{bits: 5, expected: 2},
+		{bits: 128, expected: 32},
+		{bits: 256, expected: 64},
+		{bits: 4096, expected: 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d bits", tt.bits), func(t *testing.T) {
+			password, err := generatePassword(tt.bits)
+			assert.NoError(t, err)
+			assert.Len(t, password, tt.expected)
+			assert.Regexp(t, "^[0-9a-f]+$", password)
+		})
+	}
+}
+
+func TestAccPasswordEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"redisacl": testAccProtoV6ProviderFactories["redisacl"],
+			"echo":     echoprovider.NewProviderServer(),
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPasswordEphemeralResourceConfig(128, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("value"), knownvalue.StringRegexp(regexp.MustCompile("^[0-9a-f]{32}$"))),
+				},
+			},
+			{
+				Config: testAccPasswordEphemeralResourceConfig(64, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("value"), knownvalue.StringRegexp(regexp.MustCompile("^[0-9a-f]{16}$"))),
+				},
+			},
+		},
+	})
+}
+
+func testAccPasswordEphemeralResourceConfig(bits int, offline bool) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+ephemeral "redisacl_password" "test" {
+  bits    = %d
+  offline = %t
+}
+
+provider "echo" {
+  data = ephemeral.redisacl_password.test
+}
+
+resource "echo" "test" {}
+`, bits, offline)
+}
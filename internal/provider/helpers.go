@@ -5,10 +5,17 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
 )
 
 func buildACLSetUserRules(data *ACLUserResourceModel) []string {
@@ -24,18 +31,29 @@ func buildACLSetUserRules(data *ACLUserResourceModel) []string {
 		}
 	}
 
-	if !data.Passwords.IsNull() {
+	if !data.Passwords.IsNull() || !data.PasswordHashes.IsNull() || !data.PasswordsWO.IsNull() {
 		rules = append(rules, "resetpass")
-		if len(data.Passwords.Elements()) == 0 {
+		var passwordRules []string
+		for _, password := range data.Passwords.Elements() {
+			passwordRules = append(passwordRules, ">"+password.(types.String).ValueString())
+		}
+		for _, password := range data.PasswordsWO.Elements() {
+			passwordRules = append(passwordRules, ">"+password.(types.String).ValueString())
+		}
+		for _, hash := range data.PasswordHashes.Elements() {
+			passwordRules = append(passwordRules, "#"+hash.(types.String).ValueString())
+		}
+		if len(passwordRules) == 0 {
 			rules = append(rules, "nopass")
 		} else {
-			for _, password := range data.Passwords.Elements() {
-				rules = append(rules, ">"+password.(types.String).ValueString())
-			}
+			rules = append(rules, passwordRules...)
 		}
 	}
 
-	if !data.Keys.IsNull() {
+	if hasKeyPermissions(data) {
+		rules = append(rules, "resetkeys")
+		rules = append(rules, keyPermissionRules(data.ReadWriteKeys, data.ReadKeys, data.WriteKeys)...)
+	} else if !data.Keys.IsNull() {
 		rules = append(rules, "resetkeys")
 		rules = append(rules, strings.Fields(data.Keys.ValueString())...)
 	} else {
@@ -49,7 +67,9 @@ func buildACLSetUserRules(data *ACLUserResourceModel) []string {
 		rules = append(rules, "&*")
 	}
 
-	if !data.Commands.IsNull() {
+	if hasCommandPermissions(data) {
+		rules = append(rules, commandPermissionRules(data)...)
+	} else if !data.Commands.IsNull() {
 		commands := data.Commands.ValueString()
 		if !strings.HasPrefix(commands, "-@all") {
 			rules = append(rules, "-@all")
@@ -64,10 +84,287 @@ func buildACLSetUserRules(data *ACLUserResourceModel) []string {
 			rules = append(rules, "("+selector.(types.String).ValueString()+")")
 		}
 	}
+	for _, selector := range data.Selector {
+		rules = append(rules, "("+strings.Join(selectorRules(selector), " ")+")")
+	}
 
 	return rules
 }
 
+// hasKeyPermissions reports whether key access is managed through the
+// read_keys, write_keys and readwrite_keys attributes instead of keys.
+func hasKeyPermissions(data *ACLUserResourceModel) bool {
+	return !data.ReadWriteKeys.IsNull() || !data.ReadKeys.IsNull() || !data.WriteKeys.IsNull()
+}
+
+// keyPermissionRules turns sets of key patterns into "~", "%R~" and "%W~"
+// rules.
+func keyPermissionRules(readWrite, read, write types.Set) []string {
+	var rules []string
+	for _, pattern := range readWrite.Elements() {
+		rules = append(rules, "~"+pattern.(types.String).ValueString())
+	}
+	for _, pattern := range read.Elements() {
+		rules = append(rules, "%R~"+pattern.(types.String).ValueString())
+	}
+	for _, pattern := range write.Elements() {
+		rules = append(rules, "%W~"+pattern.(types.String).ValueString())
+	}
+	return rules
+}
+
+// splitKeyPermissions sorts the key rules returned by ACL GETUSER into
+// read-write, read-only and write-only patterns.
+func splitKeyPermissions(rules []string) (readWrite, read, write []string) {
+	for _, rule := range rules {
+		switch {
+		case rule == "allkeys":
+			readWrite = append(readWrite, "*")
+		case strings.HasPrefix(rule, "~"):
+			readWrite = append(readWrite, strings.TrimPrefix(rule, "~"))
+		case strings.HasPrefix(rule, "%RW~"), strings.HasPrefix(rule, "%WR~"):
+			readWrite = append(readWrite, rule[len("%RW~"):])
+		case strings.HasPrefix(rule, "%R~"):
+			read = append(read, strings.TrimPrefix(rule, "%R~"))
+		case strings.HasPrefix(rule, "%W~"):
+			write = append(write, strings.TrimPrefix(rule, "%W~"))
+		}
+	}
+	return readWrite, read, write
+}
+
+// parsedSet builds a set of values parsed from Redis. An attribute that is
+// not managed stays null unless Redis reports values for it, so that no
+// spurious empty set shows up in the plan.
+func parsedSet(current types.Set, values []string) types.Set {
+	if len(values) == 0 && current.IsNull() {
+		return current
+	}
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// selectorRules turns a selector block into the rules between its
+// parentheses: key patterns, then channels, then commands.
+func selectorRules(selector ACLSelectorModel) []string {
+	rules := keyPermissionRules(selector.Keys, selector.ReadKeys, selector.WriteKeys)
+	for _, channel := range sortedStrings(selector.Channels) {
+		rules = append(rules, "&"+channel)
+	}
+	if !selector.Commands.IsNull() {
+		rules = append(rules, strings.Fields(selector.Commands.ValueString())...)
+	}
+	return rules
+}
+
+// parseSelector rebuilds a selector block from the fields ACL GETUSER reports
+// for it. The configured block is used to keep unmanaged sets null and to
+// keep commands as written when Redis only added the implied "-@all".
+func parseSelector(fields map[string]string, current ACLSelectorModel) ACLSelectorModel {
+	readWrite, read, write := splitKeyPermissions(strings.Fields(fields["keys"]))
+
+	var channels []string
+	for _, channel := range strings.Fields(fields["channels"]) {
+		if channel == "allchannels" {
+			channel = "&*"
+		}
+		channels = append(channels, strings.TrimPrefix(channel, "&"))
+	}
+
+	commands := types.StringValue(fields["commands"])
+	configured := current.Commands.ValueString()
+	if fields["commands"] == "-@all "+configured || fields["commands"] == "-@all" && configured == "" {
+		commands = current.Commands
+	}
+
+	return ACLSelectorModel{
+		Commands:  commands,
+		Keys:      parsedSet(current.Keys, readWrite),
+		ReadKeys:  parsedSet(current.ReadKeys, read),
+		WriteKeys: parsedSet(current.WriteKeys, write),
+		Channels:  parsedSet(current.Channels, channels),
+	}
+}
+
+// selectorsMatch reports whether two lists of selector blocks are equal.
+func selectorsMatch(a, b []ACLSelectorModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Commands.Equal(b[i].Commands) ||
+			!a[i].Keys.Equal(b[i].Keys) ||
+			!a[i].ReadKeys.Equal(b[i].ReadKeys) ||
+			!a[i].WriteKeys.Equal(b[i].WriteKeys) ||
+			!a[i].Channels.Equal(b[i].Channels) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasCommandPermissions reports whether command access is managed through
+// the structured command attributes instead of commands.
+func hasCommandPermissions(data *ACLUserResourceModel) bool {
+	return !data.AllowedCommands.IsNull() || !data.DeniedCommands.IsNull() ||
+		!data.AllowedCategories.IsNull() || !data.DeniedCategories.IsNull() ||
+		!data.AllowedSubcommands.IsNull()
+}
+
+// commandPermissionRules turns the structured command attributes into rules.
+// Rules are applied in order, so categories come first and can be refined by
+// individual commands, and allowed subcommands come last so they survive a
+// denied parent command. Values are sorted to keep the rules stable.
+func commandPermissionRules(data *ACLUserResourceModel) []string {
+	rules := []string{"-@all"}
+	for _, category := range sortedStrings(data.AllowedCategories) {
+		rules = append(rules, "+@"+category)
+	}
+	for _, category := range sortedStrings(data.DeniedCategories) {
+		rules = append(rules, "-@"+category)
+	}
+	for _, command := range sortedStrings(data.AllowedCommands) {
+		rules = append(rules, "+"+command)
+	}
+	for _, command := range sortedStrings(data.DeniedCommands) {
+		rules = append(rules, "-"+command)
+	}
+	for _, subcommand := range sortedStrings(data.AllowedSubcommands) {
+		rules = append(rules, "+"+subcommand)
+	}
+	return rules
+}
+
+// commandPermissions holds command rules returned by ACL GETUSER, sorted by
+// the attribute they belong to.
+type commandPermissions struct {
+	allowedCommands    []string
+	deniedCommands     []string
+	allowedCategories  []string
+	deniedCategories   []string
+	allowedSubcommands []string
+}
+
+// splitCommandPermissions sorts command rules into the structured command
+// attributes. The leading "-@all" is implied and dropped.
+func splitCommandPermissions(rules []string) commandPermissions {
+	var perms commandPermissions
+	for _, rule := range rules {
+		switch {
+		case rule == "-@all", rule == "nocommands":
+		case rule == "allcommands":
+			perms.allowedCategories = append(perms.allowedCategories, "all")
+		case strings.HasPrefix(rule, "+@"):
+			perms.allowedCategories = append(perms.allowedCategories, strings.TrimPrefix(rule, "+@"))
+		case strings.HasPrefix(rule, "-@"):
+			perms.deniedCategories = append(perms.deniedCategories, strings.TrimPrefix(rule, "-@"))
+		case strings.HasPrefix(rule, "+") && strings.Contains(rule, "|"):
+			perms.allowedSubcommands = append(perms.allowedSubcommands, strings.TrimPrefix(rule, "+"))
+		case strings.HasPrefix(rule, "+"):
+			perms.allowedCommands = append(perms.allowedCommands, strings.TrimPrefix(rule, "+"))
+		case strings.HasPrefix(rule, "-"):
+			perms.deniedCommands = append(perms.deniedCommands, strings.TrimPrefix(rule, "-"))
+		}
+	}
+	return perms
+}
+
+func sortedStrings(set types.Set) []string {
+	var values []string
+	for _, value := range set.Elements() {
+		values = append(values, value.(types.String).ValueString())
+	}
+	sort.Strings(values)
+	return values
+}
+
+// keepPasswordRules replaces the leading "reset" of rules built by
+// buildACLSetUserRules with the parts of it that do not touch passwords.
+// CLEARSELECTORS only exists on Redis 7, so it is only sent when selectors
+// are involved.
+func keepPasswordRules(rules []string, clearSelectors bool) []string {
+	kept := []string{"resetkeys", "resetchannels", "-@all"}
+	if clearSelectors {
+		kept = append(kept, "clearselectors")
+	}
+	for _, rule := range rules {
+		if rule != "reset" {
+			kept = append(kept, rule)
+		}
+	}
+	return kept
+}
+
+// redactedPassword stands in for passwords and password hashes changed
+// outside of Terraform, so the drift shows up in the plan without exposing
+// the server hashes.
+const redactedPassword = "(redacted)"
+
+// hashPassword returns the hex encoded SHA-256 hash Redis uses to store
+// passwords.
+func hashPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+// reconcilePasswords compares the hashes returned by ACL GETUSER with the
+// configured passwords and password hashes. Server hashes are never written
+// to state: configured hashes missing from the server are dropped, server
+// hashes that match nothing configured are reported as a redacted entry of
+// password_hashes, and drifted plaintext passwords are replaced by redacted
+// placeholders.
+func reconcilePasswords(data *ACLUserResourceModel, serverHashes []string) {
+	server := make(map[string]bool, len(serverHashes))
+	for _, hash := range serverHashes {
+		server[strings.ToLower(hash)] = true
+	}
+
+	expected := make(map[string]bool)
+	if !data.Passwords.IsNull() {
+		for _, password := range data.Passwords.Elements() {
+			expected[hashPassword(password.(types.String).ValueString())] = true
+		}
+	}
+
+	if !data.PasswordHashes.IsNull() {
+		configured := make(map[string]bool)
+		kept := []attr.Value{}
+		for _, element := range data.PasswordHashes.Elements() {
+			hash := strings.ToLower(element.(types.String).ValueString())
+			configured[hash] = true
+			if server[hash] {
+				kept = append(kept, element)
+			}
+		}
+		for hash := range server {
+			if !configured[hash] && !expected[hash] {
+				kept = append(kept, types.StringValue(redactedPassword))
+				break
+			}
+		}
+		data.PasswordHashes = types.SetValueMust(types.StringType, kept)
+	}
+
+	if !data.Passwords.IsNull() {
+		drifted := data.PasswordHashes.IsNull() && len(expected) != len(server)
+		for hash := range expected {
+			if !server[hash] {
+				drifted = true
+			}
+		}
+		if drifted {
+			redacted := make([]attr.Value, len(serverHashes))
+			for i := range redacted {
+				redacted[i] = types.StringValue(redactedPassword)
+			}
+			data.Passwords = types.ListValueMust(types.StringType, redacted)
+		}
+	}
+}
+
 func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Diagnostics) {
 	data.Enabled = types.BoolValue(false)
 
@@ -92,7 +389,26 @@ func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Dia
 				}
 			}
 		case "passwords":
-			// Skip passwords
+			// Redis only returns SHA-256 hashes. Compare them with the configured
+			// passwords and never store them in place of a password.
+			if data.Passwords.IsNull() && data.PasswordHashes.IsNull() {
+				continue
+			}
+			hashes, ok := v.([]interface{})
+			if !ok {
+				diags.AddError("Parse Error", "passwords not array")
+				return
+			}
+			var serverHashes []string
+			for _, h := range hashes {
+				hash, ok := h.(string)
+				if !ok {
+					diags.AddError("Parse Error", "password hash is not a string")
+					return
+				}
+				serverHashes = append(serverHashes, hash)
+			}
+			reconcilePasswords(data, serverHashes)
 		case "keys":
 			var keyStr string
 			switch vv := v.(type) {
@@ -113,7 +429,14 @@ func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Dia
 				diags.AddError("Parse Error", "keys not string or array")
 				return
 			}
-			data.Keys = types.StringValue(keyStr)
+			if hasKeyPermissions(data) {
+				readWrite, read, write := splitKeyPermissions(strings.Fields(keyStr))
+				data.ReadWriteKeys = parsedSet(data.ReadWriteKeys, readWrite)
+				data.ReadKeys = parsedSet(data.ReadKeys, read)
+				data.WriteKeys = parsedSet(data.WriteKeys, write)
+			} else {
+				data.Keys = types.StringValue(keyStr)
+			}
 		case "channels":
 			var chanStr string
 			switch vv := v.(type) {
@@ -141,7 +464,16 @@ func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Dia
 				diags.AddError("Parse Error", "commands not string")
 				return
 			}
-			data.Commands = types.StringValue(cmdStr)
+			if hasCommandPermissions(data) {
+				perms := splitCommandPermissions(strings.Fields(cmdStr))
+				data.AllowedCommands = parsedSet(data.AllowedCommands, perms.allowedCommands)
+				data.DeniedCommands = parsedSet(data.DeniedCommands, perms.deniedCommands)
+				data.AllowedCategories = parsedSet(data.AllowedCategories, perms.allowedCategories)
+				data.DeniedCategories = parsedSet(data.DeniedCategories, perms.deniedCategories)
+				data.AllowedSubcommands = parsedSet(data.AllowedSubcommands, perms.allowedSubcommands)
+			} else {
+				data.Commands = types.StringValue(cmdStr)
+			}
 		case "selectors":
 			sels, ok := v.([]interface{})
 			if !ok {
@@ -149,13 +481,15 @@ func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Dia
 				return
 			}
 			var selectorStrs []string
-			for _, selI := range sels {
+			var selectorBlocks []ACLSelectorModel
+			for i, selI := range sels {
 				sel, ok := selI.([]interface{})
 				if !ok {
 					diags.AddError("Parse Error", "selector not array")
 					return
 				}
 				var parts []string
+				fields := map[string]string{}
 				for j := 0; j < len(sel); j += 2 {
 					sk, ok := sel[j].(string)
 					if !ok {
@@ -184,9 +518,26 @@ func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Dia
 					}
 					if sk == "commands" || sk == "keys" || sk == "channels" {
 						parts = append(parts, sv)
+						fields[sk] = sv
 					}
 				}
 				selectorStrs = append(selectorStrs, strings.Join(parts, " "))
+
+				current := ACLSelectorModel{
+					Commands:  types.StringNull(),
+					Keys:      types.SetNull(types.StringType),
+					ReadKeys:  types.SetNull(types.StringType),
+					WriteKeys: types.SetNull(types.StringType),
+					Channels:  types.SetNull(types.StringType),
+				}
+				if i < len(data.Selector) {
+					current = data.Selector[i]
+				}
+				selectorBlocks = append(selectorBlocks, parseSelector(fields, current))
+			}
+			if len(data.Selector) > 0 {
+				data.Selector = selectorBlocks
+				break
 			}
 			selectors, d := types.ListValueFrom(context.Background(), types.StringType, selectorStrs)
 			diags.Append(d...)
@@ -194,3 +545,54 @@ func parseACLUser(acl []interface{}, data *ACLUserResourceModel, diags *diag.Dia
 		}
 	}
 }
+
+// getACLUser runs ACL GETUSER on the given node and returns the reply as the
+// flat key/value slice parseACLUser expects. A nil slice means the user does
+// not exist on that node.
+func getACLUser(ctx context.Context, node redis.UniversalClient, name string) ([]interface{}, error) {
+	result, err := node.Do(ctx, "ACL", "GETUSER", name).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var val []interface{}
+	switch res := result.(type) {
+	case []interface{}:
+		val = res
+	case map[interface{}]interface{}:
+		for k, v := range res {
+			val = append(val, k, v)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected ACL GETUSER response type %T", result)
+	}
+
+	if len(val) == 0 {
+		return nil, nil
+	}
+	return val, nil
+}
+
+// aclUserMatches reports whether two models describe the same ACL rules.
+// Passwords only differ when parseACLUser found a hash mismatch.
+func aclUserMatches(a, b *ACLUserResourceModel) bool {
+	return a.Enabled.Equal(b.Enabled) &&
+		a.Passwords.Equal(b.Passwords) &&
+		a.PasswordHashes.Equal(b.PasswordHashes) &&
+		a.Keys.Equal(b.Keys) &&
+		a.ReadWriteKeys.Equal(b.ReadWriteKeys) &&
+		a.ReadKeys.Equal(b.ReadKeys) &&
+		a.WriteKeys.Equal(b.WriteKeys) &&
+		a.Channels.Equal(b.Channels) &&
+		a.Commands.Equal(b.Commands) &&
+		a.AllowedCommands.Equal(b.AllowedCommands) &&
+		a.DeniedCommands.Equal(b.DeniedCommands) &&
+		a.AllowedCategories.Equal(b.AllowedCategories) &&
+		a.DeniedCategories.Equal(b.DeniedCategories) &&
+		a.AllowedSubcommands.Equal(b.AllowedSubcommands) &&
+		a.Selectors.Equal(b.Selectors) &&
+		selectorsMatch(a.Selector, b.Selector)
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -162,6 +163,39 @@ func TestBuildACLSetUserRules(t *testing.T) {
 			},
 			expected: []string{"reset", "on", "resetpass", "nopass", "~*", "&*", "+@all"},
 		},
+		{
+			name: "user with password hash",
+			data: &ACLUserResourceModel{
+				Enabled:        types.BoolValue(true),
+				PasswordHashes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hashPassword("password123"))}),
+			},
+			expected: []string{"reset", "on", "resetpass", "#" + hashPassword("password123"), "~*", "&*", "+@all"},
+		},
+		{
+			name: "user with password and password hash",
+			data: &ACLUserResourceModel{
+				Enabled:        types.BoolValue(true),
+				Passwords:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")}),
+				PasswordHashes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hashPassword("password2"))}),
+			},
+			expected: []string{"reset", "on", "resetpass", ">password1", "#" + hashPassword("password2"), "~*", "&*", "+@all"},
+		},
+		{
+			name: "user with write-only password",
+			data: &ACLUserResourceModel{
+				Enabled:     types.BoolValue(true),
+				PasswordsWO: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password123")}),
+			},
+			expected: []string{"reset", "on", "resetpass", ">password123", "~*", "&*", "+@all"},
+		},
+		{
+			name: "nopass user (empty password hash set)",
+			data: &ACLUserResourceModel{
+				Enabled:        types.BoolValue(true),
+				PasswordHashes: types.SetValueMust(types.StringType, []attr.Value{}),
+			},
+			expected: []string{"reset", "on", "resetpass", "nopass", "~*", "&*", "+@all"},
+		},
 		{
 			name: "user with multiple key patterns",
 			data: &ACLUserResourceModel{
@@ -170,6 +204,24 @@ func TestBuildACLSetUserRules(t *testing.T) {
 			},
 			expected: []string{"reset", "on", "resetkeys", "~key1*", "~key2*", "~key3*", "&*", "+@all"},
 		},
+		{
+			name: "user with read and write key permissions",
+			data: &ACLUserResourceModel{
+				Enabled:       types.BoolValue(true),
+				ReadWriteKeys: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("app:*")}),
+				ReadKeys:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}),
+				WriteKeys:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("queue:*")}),
+			},
+			expected: []string{"reset", "on", "resetkeys", "~app:*", "%R~logs:*", "%W~queue:*", "&*", "+@all"},
+		},
+		{
+			name: "user with only read key permissions",
+			data: &ACLUserResourceModel{
+				Enabled:  types.BoolValue(true),
+				ReadKeys: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}),
+			},
+			expected: []string{"reset", "on", "resetkeys", "%R~logs:*", "&*", "+@all"},
+		},
 		{
 			name: "user with multiple channel patterns",
 			data: &ACLUserResourceModel{
@@ -233,6 +285,37 @@ func TestBuildACLSetUserRules_Comprehensive(t *testing.T) {
 			},
 			expected: []string{"reset", "on", "resetkeys", "resetchannels", "-@all"},
 		},
+		{
+			name: "structured command permissions",
+			data: &ACLUserResourceModel{
+				Enabled: types.BoolValue(true),
+				AllowedCategories: types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue("write"),
+					types.StringValue("read"),
+				}),
+				DeniedCategories:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dangerous")}),
+				AllowedCommands:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("ping")}),
+				DeniedCommands:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("config")}),
+				AllowedSubcommands: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("config|get")}),
+			},
+			expected: []string{"reset", "on", "~*", "&*", "-@all", "+@read", "+@write", "-@dangerous", "+ping", "-config", "+config|get"},
+		},
+		{
+			name: "selector blocks",
+			data: &ACLUserResourceModel{
+				Enabled: types.BoolValue(true),
+				Selector: []ACLSelectorModel{
+					{
+						Commands:  types.StringValue("+get"),
+						Keys:      types.SetNull(types.StringType),
+						ReadKeys:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}),
+						WriteKeys: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("queue:*")}),
+						Channels:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("events")}),
+					},
+				},
+			},
+			expected: []string{"reset", "on", "~*", "&*", "+@all", "(%R~logs:* %W~queue:* &events +get)"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -242,3 +325,321 @@ func TestBuildACLSetUserRules_Comprehensive(t *testing.T) {
 		})
 	}
 }
+
+func TestACLUserMatches(t *testing.T) {
+	base := ACLUserResourceModel{
+		Enabled:            types.BoolValue(true),
+		Keys:               types.StringValue("~*"),
+		Channels:           types.StringValue("&*"),
+		Commands:           types.StringValue("+@all"),
+		Passwords:          types.ListNull(types.StringType),
+		PasswordHashes:     types.SetNull(types.StringType),
+		Selectors:          types.ListNull(types.StringType),
+		ReadKeys:           types.SetNull(types.StringType),
+		WriteKeys:          types.SetNull(types.StringType),
+		ReadWriteKeys:      types.SetNull(types.StringType),
+		AllowedCommands:    types.SetNull(types.StringType),
+		DeniedCommands:     types.SetNull(types.StringType),
+		AllowedCategories:  types.SetNull(types.StringType),
+		DeniedCategories:   types.SetNull(types.StringType),
+		AllowedSubcommands: types.SetNull(types.StringType),
+	}
+
+	same := base
+	assert.True(t, aclUserMatches(&base, &same))
+
+	redacted := base
+	redacted.Passwords = types.ListValueMust(types.StringType, []attr.Value{types.StringValue(redactedPassword)})
+	assert.False(t, aclUserMatches(&base, &redacted))
+
+	differentKeys := base
+	differentKeys.Keys = types.StringValue("~app:*")
+	assert.False(t, aclUserMatches(&base, &differentKeys))
+
+	disabled := base
+	disabled.Enabled = types.BoolValue(false)
+	assert.False(t, aclUserMatches(&base, &disabled))
+
+	withSelectors := base
+	withSelectors.Selectors = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("~key* +get")})
+	assert.False(t, aclUserMatches(&base, &withSelectors))
+}
+
+func TestParseACLUser_PasswordHashes(t *testing.T) {
+	configured := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")})
+
+	tests := []struct {
+		name      string
+		passwords types.List
+		hashes    []interface{}
+		expected  types.List
+	}{
+		{
+			name:      "matching hash keeps configured passwords",
+			passwords: configured,
+			hashes:    []interface{}{hashPassword("password1")},
+			expected:  configured,
+		},
+		{
+			name:      "password changed outside terraform",
+			passwords: configured,
+			hashes:    []interface{}{hashPassword("other")},
+			expected:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue(redactedPassword)}),
+		},
+		{
+			name:      "password added outside terraform",
+			passwords: configured,
+			hashes:    []interface{}{hashPassword("password1"), hashPassword("other")},
+			expected: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue(redactedPassword),
+				types.StringValue(redactedPassword),
+			}),
+		},
+		{
+			name:      "nopass user matches empty list",
+			passwords: types.ListValueMust(types.StringType, []attr.Value{}),
+			hashes:    []interface{}{},
+			expected:  types.ListValueMust(types.StringType, []attr.Value{}),
+		},
+		{
+			name:      "unmanaged passwords are ignored",
+			passwords: types.ListNull(types.StringType),
+			hashes:    []interface{}{hashPassword("other")},
+			expected:  types.ListNull(types.StringType),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			actual := &ACLUserResourceModel{Passwords: tt.passwords}
+			parseACLUser([]interface{}{"flags", []interface{}{"on"}, "passwords", tt.hashes}, actual, &diags)
+
+			assert.Empty(t, diags)
+			assert.True(t, tt.expected.Equal(actual.Passwords), "got %s", actual.Passwords)
+		})
+	}
+}
+
+func TestParseACLUser_ConfiguredPasswordHashes(t *testing.T) {
+	hash1 := hashPassword("password1")
+	hash2 := hashPassword("password2")
+	hash3 := hashPassword("password3")
+
+	tests := []struct {
+		name              string
+		passwords         types.List
+		passwordHashes    types.Set
+		hashes            []interface{}
+		expectedPasswords types.List
+		expectedHashes    types.Set
+	}{
+		{
+			name:              "matching hashes",
+			passwords:         types.ListNull(types.StringType),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1)}),
+			hashes:            []interface{}{hash1},
+			expectedPasswords: types.ListNull(types.StringType),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1)}),
+		},
+		{
+			name:              "hash added outside terraform",
+			passwords:         types.ListNull(types.StringType),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1)}),
+			hashes:            []interface{}{hash1, hash2, hash3},
+			expectedPasswords: types.ListNull(types.StringType),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1), types.StringValue(redactedPassword)}),
+		},
+		{
+			name:              "hash removed outside terraform",
+			passwords:         types.ListNull(types.StringType),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1), types.StringValue(hash2)}),
+			hashes:            []interface{}{hash1},
+			expectedPasswords: types.ListNull(types.StringType),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1)}),
+		},
+		{
+			name:              "uppercase configured hash",
+			passwords:         types.ListNull(types.StringType),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(strings.ToUpper(hash1))}),
+			hashes:            []interface{}{hash1},
+			expectedPasswords: types.ListNull(types.StringType),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(strings.ToUpper(hash1))}),
+		},
+		{
+			name:              "password and hash both present",
+			passwords:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")}),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash2)}),
+			hashes:            []interface{}{hash1, hash2},
+			expectedPasswords: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")}),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash2)}),
+		},
+		{
+			name:              "hash of a configured password",
+			passwords:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")}),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1)}),
+			hashes:            []interface{}{hash1},
+			expectedPasswords: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")}),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash1)}),
+		},
+		{
+			name:              "password removed outside terraform",
+			passwords:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("password1")}),
+			passwordHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash2)}),
+			hashes:            []interface{}{hash2},
+			expectedPasswords: types.ListValueMust(types.StringType, []attr.Value{types.StringValue(redactedPassword)}),
+			expectedHashes:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hash2)}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			actual := &ACLUserResourceModel{Passwords: tt.passwords, PasswordHashes: tt.passwordHashes}
+			parseACLUser([]interface{}{"flags", []interface{}{"on"}, "passwords", tt.hashes}, actual, &diags)
+
+			assert.Empty(t, diags)
+			assert.True(t, tt.expectedPasswords.Equal(actual.Passwords), "got %s", actual.Passwords)
+			assert.True(t, tt.expectedHashes.Equal(actual.PasswordHashes), "got %s", actual.PasswordHashes)
+		})
+	}
+}
+
+func TestKeepPasswordRules(t *testing.T) {
+	data := &ACLUserResourceModel{
+		Enabled:  types.BoolValue(true),
+		Keys:     types.StringValue("~app:*"),
+		Commands: types.StringValue("+get"),
+	}
+
+	assert.Equal(t,
+		[]string{"resetkeys", "resetchannels", "-@all", "on", "resetkeys", "~app:*", "&*", "-@all", "+get"},
+		keepPasswordRules(buildACLSetUserRules(data), false),
+	)
+
+	data.Selectors = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("~key* +get")})
+	assert.Equal(t,
+		[]string{"resetkeys", "resetchannels", "-@all", "clearselectors", "on", "resetkeys", "~app:*", "&*", "-@all", "+get", "(~key* +get)"},
+		keepPasswordRules(buildACLSetUserRules(data), true),
+	)
+}
+
+func TestSplitKeyPermissions(t *testing.T) {
+	readWrite, read, write := splitKeyPermissions([]string{"~app:*", "%R~logs:*", "%W~queue:*", "%RW~data:*", "allkeys"})
+
+	assert.Equal(t, []string{"app:*", "data:*", "*"}, readWrite)
+	assert.Equal(t, []string{"logs:*"}, read)
+	assert.Equal(t, []string{"queue:*"}, write)
+}
+
+func TestParseACLUser_KeyPermissions(t *testing.T) {
+	var diags diag.Diagnostics
+	actual := &ACLUserResourceModel{
+		ReadKeys: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}),
+	}
+	parseACLUser([]interface{}{
+		"flags", []interface{}{"on"},
+		"keys", "%R~logs:* %W~queue:*",
+	}, actual, &diags)
+
+	assert.Empty(t, diags)
+	assert.True(t, actual.Keys.IsNull())
+	assert.True(t, actual.ReadWriteKeys.IsNull(), "unmanaged attribute without patterns should stay null")
+	assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}).Equal(actual.ReadKeys))
+	assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("queue:*")}).Equal(actual.WriteKeys))
+}
+
+func TestSplitCommandPermissions(t *testing.T) {
+	perms := splitCommandPermissions([]string{"-@all", "+@read", "-@dangerous", "+ping", "-keys", "+config|get"})
+
+	assert.Equal(t, []string{"read"}, perms.allowedCategories)
+	assert.Equal(t, []string{"dangerous"}, perms.deniedCategories)
+	assert.Equal(t, []string{"ping"}, perms.allowedCommands)
+	assert.Equal(t, []string{"keys"}, perms.deniedCommands)
+	assert.Equal(t, []string{"config|get"}, perms.allowedSubcommands)
+}
+
+func TestParseACLUser_CommandPermissions(t *testing.T) {
+	var diags diag.Diagnostics
+	actual := &ACLUserResourceModel{
+		AllowedCategories: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("read")}),
+	}
+	parseACLUser([]interface{}{
+		"flags", []interface{}{"on"},
+		"commands", "-@all +@read +@write +config|get",
+	}, actual, &diags)
+
+	assert.Empty(t, diags)
+	assert.True(t, actual.Commands.IsNull())
+	assert.True(t, actual.DeniedCommands.IsNull(), "unmanaged attribute without rules should stay null")
+	assert.True(t, types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("read"),
+		types.StringValue("write"),
+	}).Equal(actual.AllowedCategories))
+	assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("config|get")}).Equal(actual.AllowedSubcommands))
+}
+
+func TestParseACLUser_SelectorBlocks(t *testing.T) {
+	var diags diag.Diagnostics
+	actual := &ACLUserResourceModel{
+		Selector: []ACLSelectorModel{
+			{
+				Commands:  types.StringValue("+get"),
+				Keys:      types.SetNull(types.StringType),
+				ReadKeys:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}),
+				WriteKeys: types.SetNull(types.StringType),
+				Channels:  types.SetNull(types.StringType),
+			},
+		},
+	}
+	parseACLUser([]interface{}{
+		"flags", []interface{}{"on"},
+		"selectors", []interface{}{
+			[]interface{}{"commands", "-@all +get", "keys", "%R~logs:* %W~queue:*", "channels", ""},
+			[]interface{}{"commands", "-@all +set", "keys", "~app:*", "channels", "&events"},
+		},
+	}, actual, &diags)
+
+	assert.Empty(t, diags)
+	assert.True(t, actual.Selectors.IsNull())
+	if assert.Len(t, actual.Selector, 2) {
+		first := actual.Selector[0]
+		assert.Equal(t, types.StringValue("+get"), first.Commands, "implied -@all should not show as drift")
+		assert.True(t, first.Keys.IsNull())
+		assert.True(t, first.Channels.IsNull())
+		assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("logs:*")}).Equal(first.ReadKeys))
+		assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("queue:*")}).Equal(first.WriteKeys))
+
+		second := actual.Selector[1]
+		assert.Equal(t, types.StringValue("-@all +set"), second.Commands)
+		assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("app:*")}).Equal(second.Keys))
+		assert.True(t, types.SetValueMust(types.StringType, []attr.Value{types.StringValue("events")}).Equal(second.Channels))
+	}
+}
+
+func TestUpgradeACLUserStateV0(t *testing.T) {
+	prior := aclUserResourceModelV0{
+		ID:                types.StringValue("alice"),
+		Name:              types.StringValue("alice"),
+		Enabled:           types.BoolValue(true),
+		Passwords:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("secret")}),
+		Keys:              types.StringValue("~*"),
+		Channels:          types.StringNull(),
+		Commands:          types.StringValue("+@all"),
+		Selectors:         types.ListNull(types.StringType),
+		AllowSelfMutation: types.BoolNull(),
+	}
+
+	upgraded := upgradeACLUserStateV0(prior)
+
+	assert.Equal(t, prior.Name, upgraded.Name)
+	assert.Equal(t, prior.Passwords, upgraded.Passwords)
+	assert.Equal(t, prior.Keys, upgraded.Keys)
+	assert.Equal(t, prior.Commands, upgraded.Commands)
+	assert.Equal(t, types.StringValue(onDestroyDelete), upgraded.OnDestroy)
+	assert.True(t, upgraded.PasswordHashes.IsNull())
+	assert.True(t, upgraded.ReadKeys.IsNull())
+	assert.True(t, upgraded.AllowedCommands.IsNull())
+	assert.True(t, upgraded.KillSessionsOn.IsNull())
+	assert.Nil(t, upgraded.Selector)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// lockoutProbes are the commands the provider needs to keep working. They are
+// checked with ACL DRYRUN before the provider changes its own user.
+var lockoutProbes = [][]interface{}{
+	{"ACL", "SETUSER", "lockout-probe"},
+	{"ACL", "GETUSER", "lockout-probe"},
+	{"ACL", "DELUSER", "lockout-probe"},
+	{"ACL", "WHOAMI"},
+}
+
+// checkLockout refuses a change to the user the provider is authenticated as
+// when the change would lock the provider out: the user would be disabled,
+// the provider's password would no longer be accepted, or the commands the
+// provider needs would be denied. keepPasswords tells whether the change
+// leaves the current passwords untouched.
+//
+// ACL DRYRUN only works on existing users, so the new rules are tried on a
+// temporary disabled user without passwords that is deleted afterwards.
+func (c *RedisClient) checkLockout(ctx context.Context, data *ACLUserResourceModel, keepPasswords bool, diags *diag.Diagnostics) {
+	currentUser, err := c.client.Do(ctx, "ACL", "WHOAMI").Text()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return
+	}
+	if currentUser != data.Name.ValueString() {
+		return
+	}
+
+	if data.Enabled.Equal(types.BoolValue(false)) {
+		diags.AddError("Lockout Error", fmt.Sprintf("Refusing to disable ACL user %s, which the provider is authenticated as.", currentUser))
+		return
+	}
+	if !keepPasswords && !passwordAccepted(data, c.password) {
+		diags.AddError("Lockout Error", fmt.Sprintf("Refusing to change the passwords of ACL user %s: the password the provider is authenticated with would no longer be accepted.", currentUser))
+		return
+	}
+
+	probeData := *data
+	probeData.Enabled = types.BoolValue(false)
+	probeData.Passwords = types.ListNull(types.StringType)
+	probeData.PasswordHashes = types.SetNull(types.StringType)
+	probeData.PasswordsWO = types.ListNull(types.StringType)
+	rules := buildACLSetUserRules(&probeData)
+
+	var (
+		denied      []string
+		unsupported bool
+	)
+	errs := c.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		nodeDenied, err := dryRunProbes(ctx, node, rules)
+		if isDryRunUnsupported(err) {
+			unsupported = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, command := range nodeDenied {
+			denied = append(denied, fmt.Sprintf("%s on node %s", command, node.Options().Addr))
+		}
+		return nil
+	})
+	if addNodeErrors(diags, errs, "check the provider's own permissions") {
+		return
+	}
+	if unsupported {
+		diags.AddWarning("Lockout Check Skipped", "ACL DRYRUN requires Redis 7.0 or later, so the permissions of the provider's own user were not checked.")
+	}
+	if len(denied) > 0 {
+		diags.AddError(
+			"Lockout Error",
+			fmt.Sprintf("Refusing to change ACL user %s, which the provider is authenticated as: the new rules deny %s, which the provider needs to manage ACL users.", currentUser, strings.Join(denied, ", ")),
+		)
+	}
+}
+
+// checkDestroyLockout refuses to disable or delete the user the provider is
+// authenticated as. Unlike other changes, this cannot be allowed with
+// allow_self_mutation, as the provider would lose access in the middle of
+// the apply.
+func (c *RedisClient) checkDestroyLockout(ctx context.Context, name, action string, diags *diag.Diagnostics) {
+	currentUser, err := c.client.Do(ctx, "ACL", "WHOAMI").Text()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return
+	}
+	if currentUser == name {
+		diags.AddError("Lockout Error", fmt.Sprintf("Refusing to %s ACL user %s, which the provider is authenticated as. Set on_destroy to retain to remove it from state only.", action, currentUser))
+	}
+}
+
+// dryRunProbes applies rules to a temporary user on node and returns the
+// lockout probes that ACL DRYRUN denies.
+func dryRunProbes(ctx context.Context, node *redis.Client, rules []string) ([]string, error) {
+	suffix, err := generatePassword(32)
+	if err != nil {
+		return nil, err
+	}
+	probeUser := "terraform-lockout-check-" + suffix
+
+	if err := node.ACLSetUser(ctx, probeUser, rules...).Err(); err != nil {
+		return nil, err
+	}
+	defer func() { _ = node.ACLDelUser(ctx, probeUser).Err() }()
+
+	var denied []string
+	for _, probe := range lockoutProbes {
+		args := append([]interface{}{"ACL", "DRYRUN", probeUser}, probe...)
+		result, err := node.Do(ctx, args...).Text()
+		if err != nil {
+			return nil, err
+		}
+		if result != "OK" {
+			denied = append(denied, fmt.Sprintf("%s %s", probe[0], probe[1]))
+		}
+	}
+	return denied, nil
+}
+
+// passwordAccepted reports whether the user described by data would accept
+// password once the change is applied.
+func passwordAccepted(data *ACLUserResourceModel, password string) bool {
+	if data.Passwords.IsNull() && data.PasswordHashes.IsNull() && data.PasswordsWO.IsNull() {
+		// The rules start with "reset", which removes every password.
+		return false
+	}
+	if len(data.Passwords.Elements())+len(data.PasswordHashes.Elements())+len(data.PasswordsWO.Elements()) == 0 {
+		// nopass
+		return true
+	}
+
+	for _, list := range []types.List{data.Passwords, data.PasswordsWO} {
+		for _, value := range list.Elements() {
+			if value.(types.String).ValueString() == password {
+				return true
+			}
+		}
+	}
+	hash := hashPassword(password)
+	for _, value := range data.PasswordHashes.Elements() {
+		if value.(types.String).ValueString() == hash {
+			return true
+		}
+	}
+	return false
+}
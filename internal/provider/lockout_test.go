@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordAccepted(t *testing.T) {
+	passwords := func(values ...string) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name     string
+		data     *ACLUserResourceModel
+		expected bool
+	}{
+		{
+			name: "no passwords configured",
+			data: &ACLUserResourceModel{
+				Passwords:      types.ListNull(types.StringType),
+				PasswordHashes: types.SetNull(types.StringType),
+				PasswordsWO:    types.ListNull(types.StringType),
+			},
+			expected: false,
+		},
+		{
+			name: "nopass",
+			data: &ACLUserResourceModel{
+				Passwords:      passwords(),
+				PasswordHashes: types.SetNull(types.StringType),
+				PasswordsWO:    types.ListNull(types.StringType),
+			},
+			expected: true,
+		},
+		{
+			name: "password kept",
+			data: &ACLUserResourceModel{
+				Passwords:      passwords("other", "secret"),
+				PasswordHashes: types.SetNull(types.StringType),
+				PasswordsWO:    types.ListNull(types.StringType),
+			},
+			expected: true,
+		},
+		{
+			name: "password removed",
+			data: &ACLUserResourceModel{
+				Passwords:      passwords("other"),
+				PasswordHashes: types.SetNull(types.StringType),
+				PasswordsWO:    types.ListNull(types.StringType),
+			},
+			expected: false,
+		},
+		{
+			name: "password kept as hash",
+			data: &ACLUserResourceModel{
+				Passwords:      types.ListNull(types.StringType),
+				PasswordHashes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(hashPassword("secret"))}),
+				PasswordsWO:    types.ListNull(types.StringType),
+			},
+			expected: true,
+		},
+		{
+			name: "password kept as write-only password",
+			data: &ACLUserResourceModel{
+				Passwords:      types.ListNull(types.StringType),
+				PasswordHashes: types.SetNull(types.StringType),
+				PasswordsWO:    passwords("secret"),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, passwordAccepted(tt.data, "secret"))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/redis/go-redis/v9"
+)
+
+// nodeError records a command failure on a single Redis node, or a node that
+// was skipped because it is down.
+type nodeError struct {
+	addr string
+	err  error
+	// skipped tells that fn was not called on the node, and err says why.
+	skipped bool
+}
+
+// forEachNode calls fn on every Redis node that keeps its own copy of the ACL.
+// Redis does not propagate ACL changes between nodes, so in cluster mode fn is
+// called on every master and replica, behind Sentinel it is called on the
+// master and each replica Sentinel reports, and otherwise it is called on the
+// configured server. Calls to fn are serialized, so callers may collect
+// results without additional locking. Failures are collected per node instead
+// of aborting on the first one, and are returned sorted by node address along
+// with the replicas Sentinel reports as down, which are skipped.
+func (c *RedisClient) forEachNode(ctx context.Context, fn func(ctx context.Context, node *redis.Client) error) []nodeError {
+	var (
+		mu   sync.Mutex
+		errs []nodeError
+	)
+	call := func(ctx context.Context, node *redis.Client) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := fn(ctx, node); err != nil {
+			errs = append(errs, nodeError{addr: node.Options().Addr, err: err})
+		}
+	}
+
+	switch client := c.client.(type) {
+	case *redis.ClusterClient:
+		err := client.ForEachShard(ctx, func(ctx context.Context, node *redis.Client) error {
+			call(ctx, node)
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, nodeError{addr: "cluster", err: err})
+		}
+	case *redis.Client:
+		if c.sentinel == nil {
+			call(ctx, client)
+			break
+		}
+		addrs, skipped, err := c.sentinel.nodeAddrs(ctx)
+		if err != nil {
+			errs = append(errs, nodeError{addr: "sentinel", err: err})
+			break
+		}
+		errs = append(errs, skipped...)
+		for _, addr := range addrs {
+			node := c.sentinel.newNodeClient(addr)
+			call(ctx, node)
+			_ = node.Close()
+		}
+	default:
+		errs = append(errs, nodeError{addr: "unknown", err: fmt.Errorf("unsupported client type %T", c.client)})
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].addr < errs[j].addr })
+	return errs
+}
+
+// addNodeErrors reports every per-node failure as its own error and every
+// skipped node as a warning, and returns whether any node failed.
+func addNodeErrors(diags *diag.Diagnostics, errs []nodeError, action string) bool {
+	failed := false
+	for _, e := range errs {
+		if e.skipped {
+			addSkippedNodeWarning(diags, e)
+			continue
+		}
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s on node %s, got error: %s", action, e.addr, e.err))
+		failed = true
+	}
+	return failed
+}
+
+// addSkippedNodeWarning reports a node that was neither updated nor read. The
+// message does not depend on the action, so that a node skipped by several
+// calls in the same operation is reported once.
+func addSkippedNodeWarning(diags *diag.Diagnostics, e nodeError) {
+	diags.AddWarning(
+		"Node Skipped",
+		fmt.Sprintf("Node %s was skipped because it is down (%s). It was neither updated nor checked for drift, so its ACL may be stale until the next apply after it recovers.", e.addr, e.err),
+	)
+}
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddNodeErrors(t *testing.T) {
+	skipped := nodeError{addr: "10.0.0.3:6379", err: errors.New("reported as slave,s_down by Sentinel"), skipped: true}
+	failed := nodeError{addr: "10.0.0.2:6379", err: errors.New("connection refused")}
+
+	var diags diag.Diagnostics
+	assert.False(t, addNodeErrors(&diags, []nodeError{skipped}, "create ACL user"))
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 1, diags.WarningsCount())
+	assert.Contains(t, diags[0].Detail(), "10.0.0.3:6379")
+
+	// A node skipped by several calls is reported once
+	assert.True(t, addNodeErrors(&diags, []nodeError{failed, skipped}, "persist ACL"))
+	assert.Equal(t, 1, diags.ErrorsCount())
+	assert.Equal(t, 1, diags.WarningsCount())
+	assert.Contains(t, diags.Errors()[0].Detail(), "Unable to persist ACL on node 10.0.0.2:6379")
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/redis/go-redis/v9"
+)
+
+// Supported values of the provider persistence setting.
+const (
+	persistenceNone          = "none"
+	persistenceACLSave       = "acl_save"
+	persistenceConfigRewrite = "config_rewrite"
+	persistenceAuto          = "auto"
+)
+
+var persistenceModes = []string{persistenceNone, persistenceACLSave, persistenceConfigRewrite, persistenceAuto}
+
+// persistACL writes the current ACL to disk on every node so that changes
+// survive a restart. Servers using an aclfile need ACL SAVE, while servers
+// defining users inline in redis.conf need CONFIG REWRITE.
+func (c *RedisClient) persistACL(ctx context.Context) []nodeError {
+	if c.persistence == "" || c.persistence == persistenceNone {
+		return nil
+	}
+
+	return c.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		mode := c.persistence
+		if mode == persistenceAuto {
+			detected, err := detectPersistence(ctx, node)
+			if err != nil {
+				return err
+			}
+			mode = detected
+		}
+
+		switch mode {
+		case persistenceACLSave:
+			return node.Do(ctx, "ACL", "SAVE").Err()
+		case persistenceConfigRewrite:
+			return node.ConfigRewrite(ctx).Err()
+		}
+		return nil
+	})
+}
+
+// persistConfig writes parameters changed with CONFIG SET to redis.conf on
+// every node. Only CONFIG REWRITE persists them, so it runs in the
+// config_rewrite and auto modes; acl_save only covers users.
+func (c *RedisClient) persistConfig(ctx context.Context) []nodeError {
+	if c.persistence != persistenceConfigRewrite && c.persistence != persistenceAuto {
+		return nil
+	}
+
+	return c.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		if c.persistence == persistenceAuto {
+			if err := checkConfigFile(ctx, node); err != nil {
+				return err
+			}
+		}
+		return node.ConfigRewrite(ctx).Err()
+	})
+}
+
+// detectPersistence picks ACL SAVE when the node loads its users from an
+// aclfile and CONFIG REWRITE otherwise. It fails when the node was started
+// without a config file, as CONFIG REWRITE has nothing to rewrite then.
+func detectPersistence(ctx context.Context, node *redis.Client) (string, error) {
+	config, err := node.ConfigGet(ctx, "aclfile").Result()
+	if err != nil {
+		return "", fmt.Errorf("unable to detect aclfile: %w", err)
+	}
+	if config["aclfile"] != "" {
+		return persistenceACLSave, nil
+	}
+	if err := checkConfigFile(ctx, node); err != nil {
+		return "", err
+	}
+	return persistenceConfigRewrite, nil
+}
+
+// checkConfigFile returns an error when the node was started without a
+// config file, which INFO server reports as an empty config_file.
+func checkConfigFile(ctx context.Context, node *redis.Client) error {
+	info, err := node.Info(ctx, "server").Result()
+	if err != nil {
+		return fmt.Errorf("unable to detect config file: %w", err)
+	}
+	if parseInfo(info)["config_file"] == "" {
+		return fmt.Errorf("the server was started without a config file, so CONFIG REWRITE was skipped")
+	}
+	return nil
+}
+
+// addPersistenceWarnings reports nodes on which an applied change could not
+// be persisted. The change itself succeeded, so these are warnings: failing
+// would taint a correctly created resource or keep a deleted one in state.
+func addPersistenceWarnings(diags *diag.Diagnostics, errs []nodeError, subject string) {
+	for _, e := range errs {
+		if e.skipped {
+			addSkippedNodeWarning(diags, e)
+			continue
+		}
+		diags.AddWarning(
+			"Change Not Persisted",
+			fmt.Sprintf("%s was changed but the change could not be persisted on node %s and will be lost on restart, got error: %s", subject, e.addr, e.err),
+		)
+	}
+}
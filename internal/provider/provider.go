@@ -11,10 +11,13 @@ import (
 	"os"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/redis/go-redis/v9"
@@ -24,10 +27,25 @@ import (
 type RedisClient struct {
 	client redis.UniversalClient
 	mutex  *sync.Mutex
+	// sentinel is set when connecting through Redis Sentinel, so ACL changes
+	// can be applied to the master and each of its replicas.
+	sentinel *sentinelTopology
+	// persistence controls how ACL changes are written to disk.
+	persistence string
+	// password is the password the provider authenticates with, used to make
+	// sure a change to its own user does not lock it out.
+	password string
+	// server describes the server and its ACL capabilities. It is nil when
+	// detection failed, in which case no capability is checked at plan time.
+	server *serverInfo
+	// commands caches the commands and categories of the server, read on the
+	// first plan that checks them. It is guarded by mutex.
+	commands *serverCommands
 }
 
 // Ensure RedisACLProvider satisfies various provider interfaces.
 var _ provider.Provider = &RedisACLProvider{}
+var _ provider.ProviderWithEphemeralResources = &RedisACLProvider{}
 
 // RedisACLProvider defines the provider implementation.
 type RedisACLProvider struct {
@@ -49,6 +67,7 @@ type RedisACLProviderModel struct {
 	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
 	Sentinel              types.Object `tfsdk:"sentinel"`
 	Cluster               types.Object `tfsdk:"cluster"`
+	Persistence           types.String `tfsdk:"persistence"`
 }
 
 type SentinelModel struct {
@@ -108,6 +127,13 @@ func (p *RedisACLProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				MarkdownDescription: "Disable TLS certificate verification (insecure, use only for testing).",
 				Optional:            true,
 			},
+			"persistence": schema.StringAttribute{
+				MarkdownDescription: "How ACL changes are persisted after each successful create, update or delete: `none` (default), `acl_save` (`ACL SAVE`, for servers using an `aclfile`), `config_rewrite` (`CONFIG REWRITE`, for users defined in redis.conf) or `auto` (`acl_save` when `CONFIG GET aclfile` is set, `config_rewrite` otherwise, skipped when the server was started without a config file). Failures to persist are reported as warnings, as the change itself was applied. Parameters managed by `redisacl_config` are persisted with `CONFIG REWRITE` in the `config_rewrite` and `auto` modes.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(persistenceModes...),
+				},
+			},
 			"sentinel": schema.SingleNestedAttribute{
 				MarkdownDescription: "Configuration for Redis Sentinel.",
 				Optional:            true,
@@ -188,6 +214,8 @@ func (p *RedisACLProvider) Configure(ctx context.Context, req provider.Configure
 		}
 	}
 	var client redis.UniversalClient
+	var sentinel *sentinelTopology
+	password := data.Password.ValueString()
 	// Override with REDIS_URL environment variable if set
 	redisURL := os.Getenv("REDIS_URL")
 	if redisURL != "" {
@@ -196,6 +224,7 @@ func (p *RedisACLProvider) Configure(ctx context.Context, req provider.Configure
 			resp.Diagnostics.AddError("Client Configuration", fmt.Sprintf("Invalid Redis URL: %s", err))
 			return
 		}
+		password = opts.Password
 		if tlsConfig != nil {
 			opts.TLSConfig = tlsConfig
 		}
@@ -221,6 +250,7 @@ func (p *RedisACLProvider) Configure(ctx context.Context, req provider.Configure
 			TLSConfig:        tlsConfig,
 		}
 		client = redis.NewFailoverClient(opts)
+		sentinel = &sentinelTopology{options: opts}
 	} else if !data.Cluster.IsNull() {
 		// Cluster configuration
 		var clusterModel ClusterModel
@@ -259,17 +289,35 @@ func (p *RedisACLProvider) Configure(ctx context.Context, req provider.Configure
 		resp.Diagnostics.AddError("Client Configuration", fmt.Sprintf("Unable to connect to Redis: %s", err))
 		return
 	}
+	// Detect the server flavor and version, so unsupported attributes can be
+	// rejected at plan time
+	server, err := detectServer(ctx, client, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Server Detection Failed",
+			fmt.Sprintf("Unable to detect the server flavor and version, so unsupported attributes will only be reported by the server: %s", err),
+		)
+	}
 	redisClient := &RedisClient{
-		client: client,
-		mutex:  &sync.Mutex{},
+		client:      client,
+		mutex:       &sync.Mutex{},
+		sentinel:    sentinel,
+		persistence: data.Persistence.ValueString(),
+		password:    password,
+		server:      server,
 	}
 	resp.DataSourceData = redisClient
 	resp.ResourceData = redisClient
+	resp.EphemeralResourceData = redisClient
 }
 
 func (p *RedisACLProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewACLUserResource,
+		NewACLUsersExclusiveResource,
+		NewACLDefaultUserResource,
+		NewACLLogResetResource,
+		NewACLConfigResource,
 	}
 }
 
@@ -277,6 +325,16 @@ func (p *RedisACLProvider) DataSources(_ context.Context) []func() datasource.Da
 	return []func() datasource.DataSource{
 		NewACLUserDataSource,
 		NewACLUsersDataSource,
+		NewACLLogDataSource,
+		NewCommandCategoriesDataSource,
+		NewCommandsDataSource,
+		NewServerDataSource,
+	}
+}
+
+func (p *RedisACLProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPasswordEphemeralResource,
 	}
 }
 
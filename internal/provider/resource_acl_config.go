@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ACLConfigResource{}
+var _ resource.ResourceWithImportState = &ACLConfigResource{}
+
+// configID is the ID of the redisacl_config resource. There is a single
+// configuration per instance, so the ID is fixed.
+const configID = "config"
+
+// ACL parameters managed by redisacl_config. These are the ACL settings that
+// can be changed at runtime; aclfile can only be set in redis.conf.
+const (
+	paramACLPubsubDefault = "acl-pubsub-default"
+	paramACLLogMaxLen     = "acllog-max-len"
+)
+
+var configParameters = []string{paramACLPubsubDefault, paramACLLogMaxLen}
+
+func NewACLConfigResource() resource.Resource {
+	return &ACLConfigResource{}
+}
+
+// ACLConfigResource defines the resource implementation.
+type ACLConfigResource struct {
+	redisClient *RedisClient
+}
+
+// ACLConfigResourceModel describes the resource data model.
+type ACLConfigResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ACLPubsubDefault types.String `tfsdk:"acl_pubsub_default"`
+	ACLLogMaxLen     types.Int64  `tfsdk:"acllog_max_len"`
+}
+
+func (r *ACLConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config"
+}
+
+func (r *ACLConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the ACL parameters of the server with `CONFIG SET`, on every node in cluster and sentinel topologies. " +
+			"Parameters that are not set are left as they are and report the current value. " +
+			"A node whose value differs from the state shows up as drift and is corrected on apply. " +
+			"Destroying the resource leaves the parameters untouched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"acl_pubsub_default": schema.StringAttribute{
+				MarkdownDescription: "The `acl-pubsub-default` parameter: the channel permissions of new users, `resetchannels` or `allchannels`. Requires Redis 6.2 or later.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("resetchannels", "allchannels"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"acllog_max_len": schema.Int64Attribute{
+				MarkdownDescription: "The `acllog-max-len` parameter: the maximum number of entries kept in the `ACL LOG`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ACLConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.redisClient = redisClient
+}
+
+func (r *ACLConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ACLConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.redisClient.mutex.Lock()
+	defer r.redisClient.mutex.Unlock()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(configID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ACLConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.redisClient.mutex.Lock()
+	defer r.redisClient.mutex.Unlock()
+
+	r.read(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ACLConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.redisClient.mutex.Lock()
+	defer r.redisClient.mutex.Unlock()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(configID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Removing the resource only stops managing the parameters; their values
+	// are left in place.
+}
+
+func (r *ACLConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The values are filled in by Read
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), configID)...)
+}
+
+// apply sets the known parameters of data on every node, persists them and
+// reads back the parameters left unset.
+func (r *ACLConfigResource) apply(ctx context.Context, data *ACLConfigResourceModel, diags *diag.Diagnostics) {
+	values := data.parameters()
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		for _, parameter := range configParameters {
+			if value, ok := values[parameter]; ok {
+				if err := node.ConfigSet(ctx, parameter, value).Err(); err != nil {
+					return fmt.Errorf("%s: %w", parameter, err)
+				}
+			}
+		}
+		return nil
+	})
+	if addNodeErrors(diags, errs, "set ACL configuration") {
+		return
+	}
+
+	addPersistenceWarnings(diags, r.redisClient.persistConfig(ctx), "ACL configuration")
+
+	r.read(ctx, data, diags)
+}
+
+// read refreshes data with the parameters of every node. When nodes disagree
+// with data, the value of the first such node is kept so that the plan shows
+// the drift.
+func (r *ACLConfigResource) read(ctx context.Context, data *ACLConfigResourceModel, diags *diag.Diagnostics) {
+	nodeValues := map[string]map[string]string{}
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		config, err := node.ConfigGet(ctx, "acl*").Result()
+		if err != nil {
+			return err
+		}
+		for _, parameter := range configParameters {
+			if value, ok := config[parameter]; ok {
+				if nodeValues[parameter] == nil {
+					nodeValues[parameter] = map[string]string{}
+				}
+				nodeValues[parameter][node.Options().Addr] = value
+			}
+		}
+		return nil
+	})
+	if addNodeErrors(diags, errs, "read ACL configuration") {
+		return
+	}
+
+	current := data.parameters()
+	values := map[string]string{}
+	for _, parameter := range configParameters {
+		if nodeValues[parameter] != nil {
+			values[parameter] = driftedValue(current[parameter], nodeValues[parameter])
+		}
+	}
+	if err := data.setParameters(values); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse ACL configuration, got error: %s", err))
+	}
+}
+
+// parameters returns the known, non-null parameters of m keyed by name.
+func (m *ACLConfigResourceModel) parameters() map[string]string {
+	values := map[string]string{}
+	if !m.ACLPubsubDefault.IsNull() && !m.ACLPubsubDefault.IsUnknown() {
+		values[paramACLPubsubDefault] = m.ACLPubsubDefault.ValueString()
+	}
+	if !m.ACLLogMaxLen.IsNull() && !m.ACLLogMaxLen.IsUnknown() {
+		values[paramACLLogMaxLen] = strconv.FormatInt(m.ACLLogMaxLen.ValueInt64(), 10)
+	}
+	return values
+}
+
+// setParameters sets the attributes of m from parameter values keyed by name.
+// Parameters missing from values, which the server does not have, are null.
+func (m *ACLConfigResourceModel) setParameters(values map[string]string) error {
+	m.ACLPubsubDefault = types.StringNull()
+	if value, ok := values[paramACLPubsubDefault]; ok {
+		m.ACLPubsubDefault = types.StringValue(value)
+	}
+
+	m.ACLLogMaxLen = types.Int64Null()
+	if value, ok := values[paramACLLogMaxLen]; ok {
+		maxLen, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", paramACLLogMaxLen, value, err)
+		}
+		m.ACLLogMaxLen = types.Int64Value(maxLen)
+	}
+	return nil
+}
+
+// driftedValue returns the value of the first node, by address, whose value
+// differs from current, or current when every node agrees with it.
+func driftedValue(current string, nodeValues map[string]string) string {
+	addrs := make([]string, 0, len(nodeValues))
+	for addr := range nodeValues {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	for _, addr := range addrs {
+		if nodeValues[addr] != current {
+			return nodeValues[addr]
+		}
+	}
+	return current
+}
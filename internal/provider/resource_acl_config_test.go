@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccACLConfigResource(t *testing.T) {
+	t.Cleanup(func() { testAccRestoreACLConfig(t) })
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccACLConfigResourceConfig("allchannels", 64),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_config.test", "id", "config"),
+					resource.TestCheckResourceAttr("redisacl_config.test", "acl_pubsub_default", "allchannels"),
+					resource.TestCheckResourceAttr("redisacl_config.test", "acllog_max_len", "64"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "redisacl_config.test",
+				ImportState:       true,
+				ImportStateId:     "config",
+				ImportStateVerify: true,
+			},
+			// Update testing
+			{
+				Config: testAccACLConfigResourceConfig("resetchannels", 32),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_config.test", "acl_pubsub_default", "resetchannels"),
+					resource.TestCheckResourceAttr("redisacl_config.test", "acllog_max_len", "32"),
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					if err := SetConfigInRedis(context.Background(), "acllog-max-len", "256"); err != nil {
+						t.Fatalf("Failed to modify config in Redis: %v", err)
+					}
+				},
+				Config:             testAccACLConfigResourceConfig("resetchannels", 32),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Apply restores the configured value
+				Config: testAccACLConfigResourceConfig("resetchannels", 32),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_config.test", "acllog_max_len", "32"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccACLConfigResource_Unset(t *testing.T) {
+	t.Cleanup(func() { testAccRestoreACLConfig(t) })
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Unset parameters report the current value
+				Config: testAccACLConfigResourceConfigMaxLen(16),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_config.test", "acl_pubsub_default", "resetchannels"),
+					resource.TestCheckResourceAttr("redisacl_config.test", "acllog_max_len", "16"),
+				),
+			},
+			{
+				// Changes to unset parameters are not drift
+				PreConfig: func() {
+					if err := SetConfigInRedis(context.Background(), "acl-pubsub-default", "allchannels"); err != nil {
+						t.Fatalf("Failed to modify config in Redis: %v", err)
+					}
+				},
+				Config:   testAccACLConfigResourceConfigMaxLen(16),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// testAccRestoreACLConfig restores the defaults of Redis 7, which other tests
+// rely on.
+func testAccRestoreACLConfig(t *testing.T) {
+	ctx := context.Background()
+	for parameter, value := range map[string]string{"acl-pubsub-default": "resetchannels", "acllog-max-len": "128"} {
+		if err := SetConfigInRedis(ctx, parameter, value); err != nil {
+			t.Errorf("Failed to restore %s: %v", parameter, err)
+		}
+	}
+}
+
+func testAccACLConfigResourceConfig(pubsubDefault string, maxLen int) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_config" "test" {
+  acl_pubsub_default = %[1]q
+  acllog_max_len     = %[2]d
+}
+`, pubsubDefault, maxLen)
+}
+
+func testAccACLConfigResourceConfigMaxLen(maxLen int) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_config" "test" {
+  acllog_max_len = %[1]d
+}
+`, maxLen)
+}
+
+func TestDriftedValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    string
+		nodeValues map[string]string
+		expected   string
+	}{
+		{
+			name:       "nodes agree",
+			current:    "128",
+			nodeValues: map[string]string{"10.0.0.1:6379": "128", "10.0.0.2:6379": "128"},
+			expected:   "128",
+		},
+		{
+			name:       "one node drifted",
+			current:    "128",
+			nodeValues: map[string]string{"10.0.0.1:6379": "128", "10.0.0.2:6379": "64"},
+			expected:   "64",
+		},
+		{
+			name:       "first drifted node by address",
+			current:    "128",
+			nodeValues: map[string]string{"10.0.0.3:6379": "32", "10.0.0.2:6379": "64"},
+			expected:   "64",
+		},
+		{
+			name:       "unknown current value",
+			current:    "",
+			nodeValues: map[string]string{"10.0.0.2:6379": "64", "10.0.0.1:6379": "128"},
+			expected:   "128",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, driftedValue(tt.current, tt.nodeValues))
+		})
+	}
+}
+
+func TestACLConfigResourceModelParameters(t *testing.T) {
+	var data ACLConfigResourceModel
+	assert.NoError(t, data.setParameters(map[string]string{"acl-pubsub-default": "allchannels", "acllog-max-len": "64"}))
+	assert.Equal(t, "allchannels", data.ACLPubsubDefault.ValueString())
+	assert.Equal(t, int64(64), data.ACLLogMaxLen.ValueInt64())
+	assert.Equal(t, map[string]string{"acl-pubsub-default": "allchannels", "acllog-max-len": "64"}, data.parameters())
+
+	// Parameters the server does not have are null
+	assert.NoError(t, data.setParameters(map[string]string{"acllog-max-len": "128"}))
+	assert.True(t, data.ACLPubsubDefault.IsNull())
+	assert.Equal(t, map[string]string{"acllog-max-len": "128"}, data.parameters())
+
+	assert.Error(t, data.setParameters(map[string]string{"acllog-max-len": "many"}))
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ACLDefaultUserResource{}
+var _ resource.ResourceWithImportState = &ACLDefaultUserResource{}
+
+// defaultUserName is the name of the built-in user every Redis server has.
+const defaultUserName = "default"
+
+// defaultUserBaseline are the rules applied to the default user when the
+// resource is destroyed: the permissions Redis gives it out of the box. Its
+// passwords are kept, so that destroying the resource never leaves the server
+// open without authentication.
+var defaultUserBaseline = []string{"on", "resetkeys", "allkeys", "resetchannels", "allchannels", "allcommands"}
+
+func NewACLDefaultUserResource() resource.Resource {
+	return &ACLDefaultUserResource{}
+}
+
+// ACLDefaultUserResource manages the built-in default user. It shares the
+// schema and the read and update logic of ACLUserResource, but adopts the
+// existing user instead of creating it and restores a baseline instead of
+// deleting it.
+type ACLDefaultUserResource struct {
+	ACLUserResource
+}
+
+func (r *ACLDefaultUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_user"
+}
+
+func (r *ACLDefaultUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.ACLUserResource.Schema(ctx, req, resp)
+
+	resp.Schema.MarkdownDescription = "Manages the built-in `default` Redis ACL user. " +
+		"The existing user is adopted instead of created. Unless `on_destroy` says otherwise, destroying the resource restores the " +
+		"permissions Redis gives it out of the box (`on ~* &* +@all`) while keeping its passwords."
+	resp.Schema.Attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "The name of the user, always `default`.",
+		Computed:            true,
+		Default:             stringdefault.StaticString(defaultUserName),
+	}
+	resp.Schema.Attributes["on_destroy"] = schema.StringAttribute{
+		MarkdownDescription: "What happens to the default user when the resource is destroyed: `delete` (default) restores the baseline, " +
+			"`disable` turns it `off` and removes its passwords, and `retain` leaves it untouched and only removes it from state.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onDestroyDelete),
+		Validators: []validator.String{
+			stringvalidator.OneOf(onDestroyModes...),
+		},
+	}
+}
+
+func (r *ACLDefaultUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ACLUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// Write-only values are only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passwords_wo"), &data.PasswordsWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The default user already exists, so creating the resource modifies it
+	r.checkSelfMutation(ctx, &data, "modify", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the provider does not lock itself out
+	r.redisClient.checkLockout(ctx, &data, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules := buildACLSetUserRules(&data)
+
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		acl, err := getACLUser(ctx, node, defaultUserName)
+		if err != nil {
+			return err
+		}
+		if acl == nil {
+			return fmt.Errorf("the default user does not exist")
+		}
+		return node.ACLSetUser(ctx, defaultUserName, rules...).Err()
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "adopt default ACL user") {
+		return
+	}
+
+	data.ID = data.Name
+	data.PasswordsWO = types.ListNull(types.StringType)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	addPersistenceWarnings(&resp.Diagnostics, r.redisClient.persistACL(ctx), "ACL user default")
+
+	r.redisClient.verifyAssertions(ctx, &data, &resp.Diagnostics)
+}
+
+func (r *ACLDefaultUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ACLUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rules []string
+	switch data.OnDestroy.ValueString() {
+	case onDestroyRetain:
+		return
+	case onDestroyDisable:
+		r.checkSelfMutation(ctx, &data, "disable", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.redisClient.checkDestroyLockout(ctx, defaultUserName, "disable", &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		rules = []string{"off", "resetpass"}
+	default:
+		// The default user cannot be deleted, so it is restored instead
+		rules = defaultUserBaseline
+		if !data.Selectors.IsNull() || len(data.Selector) > 0 {
+			rules = append([]string{"clearselectors"}, rules...)
+		}
+	}
+
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		return node.ACLSetUser(ctx, defaultUserName, rules...).Err()
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "restore default ACL user") {
+		return
+	}
+
+	if killSessionsOnDestroy(&data) {
+		r.redisClient.killSessions(ctx, defaultUserName, &resp.Diagnostics)
+	}
+
+	addPersistenceWarnings(&resp.Diagnostics, r.redisClient.persistACL(ctx), "ACL user default")
+}
+
+func (r *ACLDefaultUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), defaultUserName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), defaultUserName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDelete)...)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccACLDefaultUserResource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDefaultUserRestored,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLDefaultUserResourceConfig("~app:*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_default_user.test", "id", "default"),
+					resource.TestCheckResourceAttr("redisacl_default_user.test", "name", "default"),
+					resource.TestCheckResourceAttr("redisacl_default_user.test", "keys", "~app:*"),
+					testAccCheckACLUserCanAuthenticate("default", "testpass"),
+				),
+			},
+			// Apply again to ensure no drift detected
+			{
+				Config:   testAccACLDefaultUserResourceConfig("~app:*"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "redisacl_default_user.test",
+				ImportState:             true,
+				ImportStateId:           "default",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passwords", "allow_self_mutation"},
+			},
+		},
+	})
+}
+
+func TestAccACLDefaultUserResource_SelfMutation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "redisacl" {}
+
+resource "redisacl_default_user" "test" {
+  enabled = false
+}
+`,
+				ExpectError: regexp.MustCompile(`Self-Mutation Error`),
+			},
+		},
+	})
+}
+
+func TestAccACLDefaultUserResource_Lockout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccACLDefaultUserResourceConfigLockout(`["testpass"]`, "+@all -@admin"),
+				ExpectError: regexp.MustCompile(`the new rules deny ACL SETUSER`),
+			},
+			{
+				Config:      testAccACLDefaultUserResourceConfigLockout(`["otherpass"]`, "+@all"),
+				ExpectError: regexp.MustCompile(`would no longer be accepted`),
+			},
+			{
+				Config: testAccACLDefaultUserResourceConfigLockout(`["testpass"]`, "+@all"),
+				Check:  testAccCheckACLUserCanAuthenticate("default", "testpass"),
+			},
+		},
+	})
+}
+
+func TestAccACLDefaultUserResource_DisableLockout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDefaultUserRestored,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLDefaultUserResourceConfigOnDestroy("disable"),
+			},
+			{
+				Config:      testAccACLDefaultUserResourceConfigOnDestroy("disable"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Refusing to disable ACL user default`),
+			},
+			{
+				Config: testAccACLDefaultUserResourceConfigOnDestroy("delete"),
+				Check:  testAccCheckACLUserCanAuthenticate("default", "testpass"),
+			},
+		},
+	})
+}
+
+// testAccCheckDefaultUserRestored verifies that destroying the resource put
+// the default user back to its baseline without removing its password.
+func testAccCheckDefaultUserRestored(_ *terraform.State) error {
+	ctx := context.Background()
+
+	keys, err := GetUserField(ctx, "default", "keys")
+	if err != nil {
+		return err
+	}
+	if keys != "~*" {
+		return fmt.Errorf("expected default user keys to be restored to ~*, got %v", keys)
+	}
+
+	ok, err := UserCanAuthenticate(ctx, "default", "testpass")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("default user lost its password on destroy")
+	}
+	return nil
+}
+
+func testAccACLDefaultUserResourceConfig(keys string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_default_user" "test" {
+  enabled             = true
+  passwords           = ["testpass"]
+  keys                = "%s"
+  channels            = "&*"
+  commands            = "+@all"
+  allow_self_mutation = true
+}
+`, keys)
+}
+
+func testAccACLDefaultUserResourceConfigLockout(passwords, commands string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_default_user" "test" {
+  enabled             = true
+  passwords           = %s
+  keys                = "~*"
+  channels            = "&*"
+  commands            = "%s"
+  allow_self_mutation = true
+}
+`, passwords, commands)
+}
+
+func testAccACLDefaultUserResourceConfigOnDestroy(onDestroy string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_default_user" "test" {
+  enabled             = true
+  passwords           = ["testpass"]
+  keys                = "~*"
+  channels            = "&*"
+  commands            = "+@all"
+  allow_self_mutation = true
+  on_destroy          = "%s"
+}
+`, onDestroy)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ACLLogResetResource{}
+
+// logResetID is the ID of the redisacl_log_reset resource.
+const logResetID = "log_reset"
+
+// aclLogReadAll is passed to ACL LOG to read every entry; the log itself is
+// capped by acllog-max-len.
+const aclLogReadAll = math.MaxInt32
+
+func NewACLLogResetResource() resource.Resource {
+	return &ACLLogResetResource{}
+}
+
+// ACLLogResetResource defines the resource implementation.
+type ACLLogResetResource struct {
+	redisClient *RedisClient
+}
+
+// ACLLogResetResourceModel describes the resource data model.
+type ACLLogResetResourceModel struct {
+	ID             types.String       `tfsdk:"id"`
+	Triggers       types.Map          `tfsdk:"triggers"`
+	ClearedEntries types.Int64        `tfsdk:"cleared_entries"`
+	Entries        []ACLLogEntryModel `tfsdk:"entries"`
+}
+
+func (r *ACLLogResetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_reset"
+}
+
+func (r *ACLLogResetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clears the `ACL LOG` of every node with `ACL LOG RESET`. " +
+			"The entries are read and cleared in a single transaction per node and kept in `entries`, so no event is lost between reading and clearing. " +
+			"The log is cleared when the resource is created and again whenever `triggers` changes. Destroying the resource does nothing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that clear the log again when they change, such as the date of an audit run.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"cleared_entries": schema.Int64Attribute{
+				MarkdownDescription: "The number of entries cleared, summed over every node.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The entries that were cleared, most recently updated first.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node": schema.StringAttribute{
+							MarkdownDescription: "The address of the node that recorded the entry.",
+							Computed:            true,
+						},
+						"entry_id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the entry, unique per node.",
+							Computed:            true,
+						},
+						"count": schema.Int64Attribute{
+							MarkdownDescription: "The number of similar events grouped into the entry.",
+							Computed:            true,
+						},
+						"reason": schema.StringAttribute{
+							MarkdownDescription: "Why the event was logged: `auth`, `command`, `key` or `channel`.",
+							Computed:            true,
+						},
+						"context": schema.StringAttribute{
+							MarkdownDescription: "Where the event happened, such as `toplevel`, `multi` or `lua`.",
+							Computed:            true,
+						},
+						"object": schema.StringAttribute{
+							MarkdownDescription: "The command, key or channel that was denied.",
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "The user that triggered the event.",
+							Computed:            true,
+						},
+						"client_info": schema.StringAttribute{
+							MarkdownDescription: "The client that triggered the event, in `CLIENT LIST` format.",
+							Computed:            true,
+						},
+						"age_seconds": schema.Float64Attribute{
+							MarkdownDescription: "The age of the entry in seconds.",
+							Computed:            true,
+						},
+						"timestamp_created": schema.Int64Attribute{
+							MarkdownDescription: "When the entry was created, as a Unix time in milliseconds.",
+							Computed:            true,
+						},
+						"timestamp_last_updated": schema.Int64Attribute{
+							MarkdownDescription: "When the entry was last updated, as a Unix time in milliseconds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ACLLogResetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.redisClient = redisClient
+}
+
+func (r *ACLLogResetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ACLLogResetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.redisClient.mutex.Lock()
+	defer r.redisClient.mutex.Unlock()
+
+	data.Entries = []ACLLogEntryModel{}
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		var logCmd *redis.Cmd
+		_, err := node.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			logCmd = pipe.Do(ctx, "ACL", "LOG", aclLogReadAll)
+			pipe.Do(ctx, "ACL", "LOG", "RESET")
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		result, err := logCmd.Slice()
+		if err != nil {
+			return err
+		}
+		entries, err := parseACLLog(result, node.Options().Addr)
+		if err != nil {
+			return err
+		}
+		data.Entries = append(data.Entries, entries...)
+		return nil
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "reset ACL log") {
+		return
+	}
+
+	sortACLLogEntries(data.Entries)
+	data.ID = types.StringValue(logResetID)
+	data.ClearedEntries = types.Int64Value(int64(len(data.Entries)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLLogResetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The reset already happened; there is nothing to read back.
+}
+
+func (r *ACLLogResetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ACLLogResetResourceModel
+
+	// Every configurable attribute requires replacement, so only the
+	// computed values carried over from the state end up here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLLogResetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Cleared entries cannot be restored, so removing the resource only
+	// removes it from state.
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccACLLogResetResource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			ctx := context.Background()
+			if err := CreateTestUser(ctx, "log_reset_user", "testpass"); err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			// A failed authentication is recorded in the ACL log
+			if client, err := NewUserClient(ctx, "log_reset_user", "wrongpass"); err == nil {
+				_ = client.Close()
+				t.Fatal("Expected authentication with a wrong password to fail")
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLLogResetResourceConfig("first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_log_reset.test", "id", "log_reset"),
+					resource.TestCheckResourceAttrWith("redisacl_log_reset.test", "cleared_entries", func(value string) error {
+						if n, _ := strconv.Atoi(value); n < 1 {
+							return fmt.Errorf("Expected at least 1 cleared entry, got %s", value)
+						}
+						return nil
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("redisacl_log_reset.test", "entries.*", map[string]string{
+						"reason":   "auth",
+						"username": "log_reset_user",
+					}),
+					testAccCheckACLLogEmpty(),
+				),
+			},
+			{
+				// Changing the triggers clears the log again
+				Config: testAccACLLogResetResourceConfig("second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_log_reset.test", "cleared_entries", "0"),
+					resource.TestCheckResourceAttr("redisacl_log_reset.test", "entries.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckACLLogEmpty() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		length, err := ACLLogLength(context.Background())
+		if err != nil {
+			return err
+		}
+		if length != 0 {
+			return fmt.Errorf("Expected the ACL log to be empty, got %d entries", length)
+		}
+		return nil
+	}
+}
+
+func testAccACLLogResetResourceConfig(run string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_log_reset" "test" {
+  triggers = {
+    run = %[1]q
+  }
+}
+`, run)
+}
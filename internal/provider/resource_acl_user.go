@@ -5,14 +5,22 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
-
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/redis/go-redis/v9"
 )
@@ -20,6 +28,34 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ACLUserResource{}
 var _ resource.ResourceWithImportState = &ACLUserResource{}
+var _ resource.ResourceWithModifyPlan = &ACLUserResource{}
+
+// Modes of the on_destroy attribute.
+const (
+	onDestroyDelete  = "delete"
+	onDestroyDisable = "disable"
+	onDestroyRetain  = "retain"
+)
+
+var onDestroyModes = []string{onDestroyDelete, onDestroyDisable, onDestroyRetain}
+
+// passwordHashRegexp matches the password hash format accepted by "#<hash>"
+// rules: a SHA-256 digest as 64 lowercase hex characters.
+var passwordHashRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// keyPatternRegexp matches a bare key pattern: no whitespace and no rule prefix.
+var keyPatternRegexp = regexp.MustCompile(`^[^~%\s]\S*$`)
+
+// channelPatternRegexp matches a bare channel pattern without the & prefix.
+var channelPatternRegexp = regexp.MustCompile(`^[^&\s]\S*$`)
+
+// Command, category and subcommand names as Redis reports them in ACL
+// GETUSER: lowercase and without a rule prefix.
+var (
+	commandNameRegexp    = regexp.MustCompile(`^[^\sA-Z+\-@|][^\sA-Z|]*$`)
+	categoryNameRegexp   = regexp.MustCompile(`^[^\sA-Z+\-@|][^\sA-Z|@]*$`)
+	subcommandNameRegexp = regexp.MustCompile(`^[^\sA-Z+\-@|][^\sA-Z|]*\|[^\sA-Z|]+$`)
+)
 
 func NewACLUserResource() resource.Resource {
 	return &ACLUserResource{}
@@ -32,15 +68,39 @@ type ACLUserResource struct {
 
 // ACLUserResourceModel describes the resource data model.
 type ACLUserResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Enabled           types.Bool   `tfsdk:"enabled"`
-	Passwords         types.List   `tfsdk:"passwords"`
-	Keys              types.String `tfsdk:"keys"`
-	Channels          types.String `tfsdk:"channels"`
-	Commands          types.String `tfsdk:"commands"`
-	Selectors         types.List   `tfsdk:"selectors"`
-	AllowSelfMutation types.Bool   `tfsdk:"allow_self_mutation"`
+	ID                 types.String       `tfsdk:"id"`
+	Name               types.String       `tfsdk:"name"`
+	Enabled            types.Bool         `tfsdk:"enabled"`
+	Passwords          types.List         `tfsdk:"passwords"`
+	PasswordHashes     types.Set          `tfsdk:"password_hashes"`
+	PasswordsWO        types.List         `tfsdk:"passwords_wo"`
+	PasswordsWOVersion types.Int64        `tfsdk:"passwords_wo_version"`
+	Keys               types.String       `tfsdk:"keys"`
+	ReadKeys           types.Set          `tfsdk:"read_keys"`
+	WriteKeys          types.Set          `tfsdk:"write_keys"`
+	ReadWriteKeys      types.Set          `tfsdk:"readwrite_keys"`
+	Channels           types.String       `tfsdk:"channels"`
+	Commands           types.String       `tfsdk:"commands"`
+	AllowedCommands    types.Set          `tfsdk:"allowed_commands"`
+	DeniedCommands     types.Set          `tfsdk:"denied_commands"`
+	AllowedCategories  types.Set          `tfsdk:"allowed_categories"`
+	DeniedCategories   types.Set          `tfsdk:"denied_categories"`
+	AllowedSubcommands types.Set          `tfsdk:"allowed_subcommands"`
+	Selectors          types.List         `tfsdk:"selectors"`
+	Selector           []ACLSelectorModel `tfsdk:"selector"`
+	Assert             []ACLAssertModel   `tfsdk:"assert"`
+	AllowSelfMutation  types.Bool         `tfsdk:"allow_self_mutation"`
+	OnDestroy          types.String       `tfsdk:"on_destroy"`
+	KillSessionsOn     types.Set          `tfsdk:"kill_sessions_on"`
+}
+
+// ACLSelectorModel describes a selector block.
+type ACLSelectorModel struct {
+	Commands  types.String `tfsdk:"commands"`
+	Keys      types.Set    `tfsdk:"keys"`
+	ReadKeys  types.Set    `tfsdk:"read_keys"`
+	WriteKeys types.Set    `tfsdk:"write_keys"`
+	Channels  types.Set    `tfsdk:"channels"`
 }
 
 func (r *ACLUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +110,7 @@ func (r *ACLUserResource) Metadata(_ context.Context, req resource.MetadataReque
 func (r *ACLUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Redis ACL user.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -76,31 +137,240 @@ func (r *ACLUserResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"password_hashes": schema.SetAttribute{
+				MarkdownDescription: "A set of SHA-256 password hashes (64 lowercase hex characters) for the user. Can be used alongside or instead of `passwords` to keep plaintext secrets out of the configuration.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(passwordHashRegexp, "must be a lowercase hex encoded SHA-256 hash"),
+					),
+				},
+			},
+			"passwords_wo": schema.ListAttribute{
+				MarkdownDescription: "A write-only list of passwords for the user. The passwords are sent to Redis but never stored in the Terraform state; bump `passwords_wo_version` to rotate them. Out-of-band password changes are not detected for write-only passwords. Requires Terraform 1.11 or later.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("passwords"), path.MatchRoot("password_hashes")),
+					listvalidator.AlsoRequires(path.MatchRoot("passwords_wo_version")),
+				},
+			},
+			"passwords_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `passwords_wo`. Changing it sends the current write-only passwords to Redis.",
+				Optional:            true,
+			},
 			"keys": schema.StringAttribute{
 				MarkdownDescription: "The key patterns the user has access to (space-separated if multiple).",
 				Optional:            true,
+				Validators: []validator.String{
+					validACLRules(ruleKeys),
+				},
+			},
+			"read_keys": schema.SetAttribute{
+				MarkdownDescription: "Key patterns the user can only read, emitted as `%R~<pattern>` rules. Requires Redis 7.0 or later. Conflicts with `keys`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          keyPatternSetValidators(),
+			},
+			"write_keys": schema.SetAttribute{
+				MarkdownDescription: "Key patterns the user can only write, emitted as `%W~<pattern>` rules. Requires Redis 7.0 or later. Conflicts with `keys`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          keyPatternSetValidators(),
+			},
+			"readwrite_keys": schema.SetAttribute{
+				MarkdownDescription: "Key patterns the user can read and write, emitted as `~<pattern>` rules. Conflicts with `keys`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          keyPatternSetValidators(),
 			},
 			"channels": schema.StringAttribute{
 				MarkdownDescription: "The channel patterns the user has access to (space-separated if multiple).",
 				Optional:            true,
+				Validators: []validator.String{
+					validACLRules(ruleChannels),
+				},
 			},
 			"commands": schema.StringAttribute{
 				MarkdownDescription: "The commands the user can execute (space-separated).",
 				Optional:            true,
+				Validators: []validator.String{
+					validACLRules(ruleCommands),
+				},
+			},
+			"allowed_commands": schema.SetAttribute{
+				MarkdownDescription: "Commands the user can execute, emitted as `+<command>` rules. Conflicts with `commands`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          commandSetValidators(commandNameRegexp, "must be a lowercase command name without a + or - prefix"),
+			},
+			"denied_commands": schema.SetAttribute{
+				MarkdownDescription: "Commands the user cannot execute, emitted as `-<command>` rules after the allowed categories and commands. Conflicts with `commands`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          commandSetValidators(commandNameRegexp, "must be a lowercase command name without a + or - prefix"),
+			},
+			"allowed_categories": schema.SetAttribute{
+				MarkdownDescription: "Command categories the user can execute, such as `read`, emitted as `+@<category>` rules. Conflicts with `commands`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          commandSetValidators(categoryNameRegexp, "must be a lowercase category name without a +@ or -@ prefix"),
+			},
+			"denied_categories": schema.SetAttribute{
+				MarkdownDescription: "Command categories the user cannot execute, such as `dangerous`, emitted as `-@<category>` rules. Conflicts with `commands`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          commandSetValidators(categoryNameRegexp, "must be a lowercase category name without a +@ or -@ prefix"),
+			},
+			"allowed_subcommands": schema.SetAttribute{
+				MarkdownDescription: "Subcommands the user can execute, written as `<command>|<subcommand>` such as `config|get`. Conflicts with `commands`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          commandSetValidators(subcommandNameRegexp, "must be a lowercase <command>|<subcommand> pair without a + or - prefix"),
 			},
 			"selectors": schema.ListAttribute{
-				MarkdownDescription: "A list of selectors for the user (each a string of space-separated rules).",
+				MarkdownDescription: "A list of selectors for the user (each a string of space-separated rules). Conflicts with `selector`.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validACLRules(ruleSelectors)),
+				},
 			},
 			"allow_self_mutation": schema.BoolAttribute{
-				MarkdownDescription: "Whether to allow the user to modify itself.",
+				MarkdownDescription: "Whether to allow the user to modify itself. Changes that would lock the provider out are still refused: disabling the user, deleting or disabling it on destroy, dropping the password the provider uses, or denying `ACL SETUSER`, `ACL GETUSER`, `ACL DELUSER` or `ACL WHOAMI` (checked with `ACL DRYRUN` on Redis 7+).",
 				Optional:            true,
 			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the user when the resource is destroyed: `delete` (default) deletes it, " +
+					"`disable` turns it `off` and removes its passwords but keeps it for forensics, and `retain` leaves it untouched and only removes it from state.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyModes...),
+				},
+			},
+			"kill_sessions_on": schema.SetAttribute{
+				MarkdownDescription: "Events after which clients already authenticated as the user are disconnected with `CLIENT KILL USER` on every node: " +
+					"`disable` when the user is disabled, `password_change` when its passwords change, `any_change` on every change, and `delete` when the resource is destroyed. " +
+					"Redis keeps existing connections open otherwise.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(killSessionsEvents...)),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"assert": schema.ListNestedBlock{
+				MarkdownDescription: "A permission the user is expected to have or lack, verified with `ACL DRYRUN` on every node after each create and update. " +
+					"The apply fails when an expectation does not hold. Requires Redis 7.0 or later.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"command": schema.ListAttribute{
+							MarkdownDescription: "The command and its arguments, such as `[\"GET\", \"app:1\"]`.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"expect": schema.StringAttribute{
+							MarkdownDescription: "The expected outcome, `allowed` (default) or `denied`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(assertAllowed),
+							Validators: []validator.String{
+								stringvalidator.OneOf(assertAllowed, assertDenied),
+							},
+						},
+					},
+				},
+			},
+			"selector": schema.ListNestedBlock{
+				MarkdownDescription: "A selector granting an additional, independent set of permissions (Redis 7+). Each block is emitted as a `(...)` rule. Conflicts with `selectors`.",
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("selectors")),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"commands": schema.StringAttribute{
+							MarkdownDescription: "Command rules for the selector (space-separated), such as `+get +@read`. Selectors start with no commands.",
+							Optional:            true,
+							Validators: []validator.String{
+								validACLRules(ruleCommands),
+							},
+						},
+						"keys": schema.SetAttribute{
+							MarkdownDescription: "Read-write key patterns, emitted as `~` rules.",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Validators:          selectorPatternValidators(),
+						},
+						"read_keys": schema.SetAttribute{
+							MarkdownDescription: "Read-only key patterns, emitted as `%R~` rules.",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Validators:          selectorPatternValidators(),
+						},
+						"write_keys": schema.SetAttribute{
+							MarkdownDescription: "Write-only key patterns, emitted as `%W~` rules.",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Validators:          selectorPatternValidators(),
+						},
+						"channels": schema.SetAttribute{
+							MarkdownDescription: "Pub/Sub channel patterns, emitted as `&` rules.",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(
+									stringvalidator.RegexMatches(channelPatternRegexp, "must be a channel pattern without the & prefix"),
+								),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// keyPatternSetValidators validates the elements of the read_keys, write_keys
+// and readwrite_keys attributes, which hold bare patterns without a prefix.
+func keyPatternSetValidators() []validator.Set {
+	return []validator.Set{
+		setvalidator.ConflictsWith(path.MatchRoot("keys")),
+		setvalidator.ValueStringsAre(
+			stringvalidator.RegexMatches(keyPatternRegexp, "must be a single key pattern without a ~ or %R~/%W~ prefix"),
+		),
+	}
+}
+
+// selectorPatternValidators validates the key pattern sets of a selector
+// block.
+func selectorPatternValidators() []validator.Set {
+	return []validator.Set{
+		setvalidator.ValueStringsAre(
+			stringvalidator.RegexMatches(keyPatternRegexp, "must be a key pattern without the ~ or %R~/%W~ prefix"),
+		),
+	}
+}
+
+// commandSetValidators validates the elements of the structured command
+// attributes, which hold bare names without a rule prefix.
+func commandSetValidators(re *regexp.Regexp, message string) []validator.Set {
+	return []validator.Set{
+		setvalidator.ConflictsWith(path.MatchRoot("commands")),
+		setvalidator.ValueStringsAre(stringvalidator.RegexMatches(re, message)),
+	}
+}
+
 func (r *ACLUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -119,27 +389,121 @@ func (r *ACLUserResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.redisClient = redisClient
 }
 
+// ModifyPlan checks the configuration against the server, so that
+// unsupported attributes and typos in commands, subcommands and categories
+// are caught at plan time instead of being rejected by ACL SETUSER during
+// apply.
+func (r *ACLUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.redisClient == nil {
+		return
+	}
+
+	// The attributes are read one by one, as blocks may still be unknown
+	var data ACLUserResourceModel
+	for _, attribute := range []struct {
+		name   string
+		target interface{}
+	}{
+		{"commands", &data.Commands},
+		{"selectors", &data.Selectors},
+		{"allowed_commands", &data.AllowedCommands},
+		{"denied_commands", &data.DeniedCommands},
+		{"allowed_categories", &data.AllowedCategories},
+		{"denied_categories", &data.DeniedCategories},
+		{"allowed_subcommands", &data.AllowedSubcommands},
+		{"keys", &data.Keys},
+		{"read_keys", &data.ReadKeys},
+		{"write_keys", &data.WriteKeys},
+	} {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute.name), attribute.target)...)
+	}
+	var selectors, assertions types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("selector"), &selectors)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("assert"), &assertions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !selectors.IsUnknown() {
+		resp.Diagnostics.Append(selectors.ElementsAs(ctx, &data.Selector, false)...)
+	}
+	if !assertions.IsUnknown() {
+		resp.Diagnostics.Append(assertions.ElementsAs(ctx, &data.Assert, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reject the attributes the server does not support
+	if r.redisClient.server != nil {
+		r.redisClient.server.checkCapabilities(&data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	refs := commandReferences(&data)
+	if len(refs) == 0 {
+		return
+	}
+
+	r.redisClient.mutex.Lock()
+	defer r.redisClient.mutex.Unlock()
+
+	known, err := r.redisClient.loadServerCommands(ctx, refs)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Command Validation Skipped",
+			fmt.Sprintf("Unable to list the commands and categories of the server, so they were not checked at plan time, got error: %s", err),
+		)
+		return
+	}
+	known.check(refs, &resp.Diagnostics)
+}
+
 func (r *ACLUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ACLUserResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	// Write-only values are only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passwords_wo"), &data.PasswordsWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The user may already exist, in which case it may be the provider's own
+	// user and creating the resource resets it
+	r.checkSelfMutation(ctx, &data, "modify", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the provider does not lock itself out
+	r.redisClient.checkLockout(ctx, &data, false, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	rules := buildACLSetUserRules(&data)
 
-	err := r.redisClient.client.ACLSetUser(ctx, data.Name.ValueString(), rules...).Err()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ACL user, got error: %s", err))
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		return node.ACLSetUser(ctx, data.Name.ValueString(), rules...).Err()
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "create ACL user") {
 		return
 	}
 
 	// Set the ID to the user name
 	data.ID = data.Name
+	data.PasswordsWO = types.ListNull(types.StringType)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	addPersistenceWarnings(&resp.Diagnostics, r.redisClient.persistACL(ctx), "ACL user "+data.Name.ValueString())
+
+	r.redisClient.verifyAssertions(ctx, &data, &resp.Diagnostics)
 }
 
 func (r *ACLUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -154,52 +518,95 @@ func (r *ACLUserResource) Read(ctx context.Context, req resource.ReadRequest, re
 	r.redisClient.mutex.Lock()
 	defer r.redisClient.mutex.Unlock()
 
-	result, err := r.redisClient.client.Do(ctx, "ACL", "GETUSER", data.Name.ValueString()).Result()
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			resp.State.RemoveResource(ctx)
-			return
+	// ACLs are kept per node, so read the user from every node and compare.
+	type nodeACL struct {
+		addr string
+		acl  []interface{}
+	}
+	var nodes []nodeACL
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		acl, err := getACLUser(ctx, node, data.Name.ValueString())
+		if err != nil {
+			return err
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ACL user result, got error: %s", err))
+		nodes = append(nodes, nodeACL{addr: node.Options().Addr, acl: acl})
+		return nil
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "read ACL user") {
 		return
 	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].addr < nodes[j].addr })
+
+	state := data
+	var missing []string
+	var parsed []ACLUserResourceModel
+	var addrs []string
+	for _, node := range nodes {
+		if node.acl == nil {
+			missing = append(missing, node.addr)
+			continue
+		}
 
-	var val []interface{}
-	switch res := result.(type) {
-	case []interface{}:
-		val = res
-	case map[interface{}]interface{}:
-		for k, v := range res {
-			val = append(val, k, v)
+		nodeData := state
+		parseACLUser(node.acl, &nodeData, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-	default:
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse ACL GETUSER response: unexpected type %T", result))
-		return
+
+		// If the commands in the state and from the API only differ by the
+		// "-@all " prefix, keep the state as is to prevent drift. An empty
+		// commands string is reported as a bare "-@all".
+		stateCommands := state.Commands.ValueString()
+		dataCommands := nodeData.Commands.ValueString()
+
+		if stateCommands != dataCommands && dataCommands == "-@all "+stateCommands {
+			nodeData.Commands = state.Commands
+		}
+		if !state.Commands.IsNull() && stateCommands == "" && dataCommands == "-@all" {
+			nodeData.Commands = state.Commands
+		}
+
+		parsed = append(parsed, nodeData)
+		addrs = append(addrs, node.addr)
 	}
 
-	if len(val) == 0 {
+	if len(parsed) == 0 {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	parseACLUser(val, &data, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+	if len(missing) > 0 {
+		// Dropping the resource from state makes the next apply recreate the
+		// user on every node.
+		resp.Diagnostics.AddWarning(
+			"ACL User Missing On Nodes",
+			fmt.Sprintf("ACL user %s does not exist on nodes %s and will be recreated on every node.", data.Name.ValueString(), strings.Join(missing, ", ")),
+		)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// If the commands in the state and from the API only differ by the
-	// "-@all " prefix, keep the state as is to prevent drift.
-	var state ACLUserResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
+	var divergent []string
+	for i := 1; i < len(parsed); i++ {
+		if !aclUserMatches(&parsed[i], &parsed[0]) {
+			divergent = append(divergent, addrs[i])
+		}
+	}
+	if len(divergent) > 0 {
+		resp.Diagnostics.AddWarning(
+			"ACL User Diverged Between Nodes",
+			fmt.Sprintf("ACL user %s on nodes %s differs from node %s. The next apply will rewrite it on every node.", data.Name.ValueString(), strings.Join(divergent, ", "), addrs[0]),
+		)
 	}
 
-	stateCommands := state.Commands.ValueString()
-	dataCommands := data.Commands.ValueString()
-
-	if stateCommands != dataCommands && dataCommands == "-@all "+stateCommands {
-		data.Commands = state.Commands
+	// Prefer the first node that differs from the state so that drift on a
+	// single node still shows up in the plan.
+	data = parsed[0]
+	for i := range parsed {
+		if !aclUserMatches(&parsed[i], &state) {
+			data = parsed[i]
+			break
+		}
 	}
 
 	// Ensure ID is set
@@ -213,40 +620,62 @@ func (r *ACLUserResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	// Write-only values are only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passwords_wo"), &data.PasswordsWO)...)
+
+	var state ACLUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Write-only passwords may change on every run, for example when they come
+	// from an ephemeral resource, so they are only sent when their version
+	// changes. Otherwise the current passwords are left untouched.
+	keepPasswords := !data.PasswordsWO.IsNull() && data.PasswordsWOVersion.Equal(state.PasswordsWOVersion)
+	if keepPasswords {
+		data.PasswordsWO = types.ListNull(types.StringType)
+	}
+
 	// Check for self-mutation
-	if !data.AllowSelfMutation.ValueBool() {
-		result, err := r.redisClient.client.Do(ctx, "ACL", "WHOAMI").Result()
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
-			return
-		}
-		currentUser, ok := result.(string)
-		if !ok {
-			resp.Diagnostics.AddError("Client Error", "Unable to parse current user response")
-			return
-		}
-		if currentUser == data.Name.ValueString() {
-			resp.Diagnostics.AddError("Self-Mutation Error", "Cannot modify the currently authenticated user without setting allow_self_mutation to true")
-			return
-		}
+	r.checkSelfMutation(ctx, &data, "modify", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the provider does not lock itself out
+	r.redisClient.checkLockout(ctx, &data, keepPasswords, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	rules := buildACLSetUserRules(&data)
+	if keepPasswords {
+		rules = keepPasswordRules(rules, !data.Selectors.IsNull() || !state.Selectors.IsNull() ||
+			len(data.Selector) > 0 || len(state.Selector) > 0)
+	}
 
-	err := r.redisClient.client.ACLSetUser(ctx, data.Name.ValueString(), rules...).Err()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update ACL user, got error: %s", err))
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		return node.ACLSetUser(ctx, data.Name.ValueString(), rules...).Err()
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "update ACL user") {
 		return
 	}
 
+	if killSessionsOnUpdate(&state, &data, !data.PasswordsWO.IsNull()) {
+		r.redisClient.killSessions(ctx, data.Name.ValueString(), &resp.Diagnostics)
+	}
+
 	// Ensure ID is set
 	data.ID = data.Name
+	data.PasswordsWO = types.ListNull(types.StringType)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	addPersistenceWarnings(&resp.Diagnostics, r.redisClient.persistACL(ctx), "ACL user "+data.Name.ValueString())
+
+	r.redisClient.verifyAssertions(ctx, &data, &resp.Diagnostics)
 }
 
 func (r *ACLUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -258,31 +687,85 @@ func (r *ACLUserResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	// Retained users are only removed from state
+	if data.OnDestroy.ValueString() == onDestroyRetain {
+		return
+	}
+
+	action := onDestroyDelete
+	if data.OnDestroy.ValueString() == onDestroyDisable {
+		action = onDestroyDisable
+	}
+
 	// Check for self-mutation
-	if !data.AllowSelfMutation.ValueBool() {
-		result, err := r.redisClient.client.Do(ctx, "ACL", "WHOAMI").Result()
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+	r.checkSelfMutation(ctx, &data, action, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The provider's own user is never disabled or deleted
+	r.redisClient.checkDestroyLockout(ctx, data.Name.ValueString(), action, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if action == onDestroyDisable {
+		errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+			return node.ACLSetUser(ctx, data.Name.ValueString(), "off", "resetpass").Err()
+		})
+		if addNodeErrors(&resp.Diagnostics, errs, "disable ACL user") {
 			return
 		}
-		currentUser, ok := result.(string)
-		if !ok {
-			resp.Diagnostics.AddError("Client Error", "Unable to parse current user response")
-			return
+
+		if killSessionsOnDestroy(&data) {
+			r.redisClient.killSessions(ctx, data.Name.ValueString(), &resp.Diagnostics)
 		}
-		if currentUser == data.Name.ValueString() {
-			resp.Diagnostics.AddError("Self-Mutation Error", "Cannot delete the currently authenticated user without setting allow_self_mutation to true")
+
+		addPersistenceWarnings(&resp.Diagnostics, r.redisClient.persistACL(ctx), "ACL user "+data.Name.ValueString())
+		return
+	}
+
+	// Sessions are killed before the user is deleted, as CLIENT KILL USER
+	// fails for unknown users
+	if killSessionsOnDestroy(&data) {
+		r.redisClient.killSessions(ctx, data.Name.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
-	err := r.redisClient.client.ACLDelUser(ctx, data.Name.ValueString()).Err()
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		return node.ACLDelUser(ctx, data.Name.ValueString()).Err()
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "delete ACL user") {
+		return
+	}
+
+	addPersistenceWarnings(&resp.Diagnostics, r.redisClient.persistACL(ctx), "ACL user "+data.Name.ValueString())
+}
+
+// checkSelfMutation reports an error when the user is the one the provider is
+// authenticated as, unless allow_self_mutation is set.
+func (r *ACLUserResource) checkSelfMutation(ctx context.Context, data *ACLUserResourceModel, action string, diags *diag.Diagnostics) {
+	if data.AllowSelfMutation.ValueBool() {
+		return
+	}
+	result, err := r.redisClient.client.Do(ctx, "ACL", "WHOAMI").Result()
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ACL user, got error: %s", err))
+		diags.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return
+	}
+	currentUser, ok := result.(string)
+	if !ok {
+		diags.AddError("Client Error", "Unable to parse current user response")
 		return
 	}
+	if currentUser == data.Name.ValueString() {
+		diags.AddError("Self-Mutation Error", fmt.Sprintf("Cannot %s the currently authenticated user without setting allow_self_mutation to true", action))
+	}
 }
 
 func (r *ACLUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyDelete)...)
 }
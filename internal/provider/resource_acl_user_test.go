@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
 )
 
 func TestMain(m *testing.M) {
@@ -766,3 +770,843 @@ resource "redisacl_user" "test" {
 }
 `, name, channels)
 }
+
+func TestAccACLUserResource_Persistence(t *testing.T) {
+	tests := []struct {
+		persistence string
+		aclFile     bool
+		// file is where the user is expected to be persisted, if anywhere
+		file      string
+		persisted bool
+	}{
+		{persistence: "none", file: "/data/redis.conf", persisted: false},
+		{persistence: "acl_save", aclFile: true, file: "/data/users.acl", persisted: true},
+		{persistence: "config_rewrite", file: "/data/redis.conf", persisted: true},
+		{persistence: "auto", aclFile: true, file: "/data/users.acl", persisted: true},
+		{persistence: "auto", file: "/data/redis.conf", persisted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/aclfile=%t", tt.persistence, tt.aclFile), func(t *testing.T) {
+			ctx := context.Background()
+			container, url, err := StartConfigFileRedisContainer(ctx, tt.aclFile)
+			if err != nil {
+				t.Fatalf("Failed to start Redis container: %v", err)
+			}
+			t.Cleanup(func() { _ = container.Terminate(ctx) })
+			t.Setenv("REDIS_URL", url)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				// Deleting the user is persisted as well
+				CheckDestroy: func(_ *terraform.State) error {
+					return testAccCheckContainerFileContains(ctx, container, tt.file, "user persist_user ", false)
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: testAccACLUserResourceConfigPersistence("persist_user", tt.persistence),
+						Check: func(_ *terraform.State) error {
+							return testAccCheckContainerFileContains(ctx, container, tt.file, "user persist_user ", tt.persisted)
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccACLUserResource_PersistenceFailure(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The test container runs without an aclfile, so ACL SAVE
+				// fails. The user is still created and only a warning is
+				// reported, and destroying it succeeds as well.
+				Config: testAccACLUserResourceConfigPersistence("persist_user", "acl_save"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+				),
+			},
+			{
+				// Neither is there a config file, so auto skips CONFIG
+				// REWRITE. Renaming replaces the user, which persists both the
+				// deletion and the creation.
+				Config: testAccACLUserResourceConfigPersistence("persist_user_auto", "auto"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					testAccCheckACLUserDoesNotExist("persist_user"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckContainerFileContains(ctx context.Context, container testcontainers.Container, path, substr string, expected bool) error {
+	content, err := ReadContainerFile(ctx, container, path)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", path, err)
+	}
+	if strings.Contains(content, substr) != expected {
+		return fmt.Errorf("Expected %s to contain %q: %t, got:\n%s", path, substr, expected, content)
+	}
+	return nil
+}
+
+func TestAccACLUserResource_InvalidPersistence(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccACLUserResourceConfigPersistence("persist_user", "always"),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigPersistence(name, persistence string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {
+  persistence = "%s"
+}
+
+resource "redisacl_user" "test" {
+  name     = "%s"
+  enabled  = true
+  keys     = "~*"
+  channels = "&*"
+  commands = "+@all"
+}
+`, persistence, name)
+}
+
+func TestAccACLUserResource_PasswordDrift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigWithPassword("password_drift_user", "password123"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "passwords.#", "1"),
+				),
+			},
+			{
+				// Change the password outside of Terraform
+				PreConfig: func() {
+					ctx := context.Background()
+					err := ModifyUserInRedis(ctx, "password_drift_user", []string{"resetpass", ">other"})
+					if err != nil {
+						t.Fatalf("Failed to modify user in Redis: %v", err)
+					}
+				},
+				Config:             testAccACLUserResourceConfigWithPassword("password_drift_user", "password123"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Apply restores the configured password
+				Config: testAccACLUserResourceConfigWithPassword("password_drift_user", "password123"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "passwords.0", "password123"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccACLUserResource_PasswordHashes(t *testing.T) {
+	hash := hashPassword("password123")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigPasswordHash("hash_user", hash),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "password_hashes.#", "1"),
+				),
+			},
+			{
+				// Add a password outside of Terraform
+				PreConfig: func() {
+					ctx := context.Background()
+					err := ModifyUserInRedis(ctx, "hash_user", []string{">other"})
+					if err != nil {
+						t.Fatalf("Failed to modify user in Redis: %v", err)
+					}
+				},
+				Config:             testAccACLUserResourceConfigPasswordHash("hash_user", hash),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// The unknown hash is recorded as redacted, never as the hash
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "password_hashes.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "password_hashes.*", hash),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "password_hashes.*", redactedPassword),
+				),
+			},
+			{
+				// Apply removes the password added outside of Terraform
+				Config: testAccACLUserResourceConfigPasswordHash("hash_user", hash),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "password_hashes.#", "1"),
+					testAccCheckACLUserCanAuthenticate("hash_user", "password123"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccACLUserResource_InvalidPasswordHash(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccACLUserResourceConfigPasswordHash("hash_user", "not-a-hash"),
+				ExpectError: regexp.MustCompile("lowercase hex encoded SHA-256 hash"),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigPasswordHash(name, hash string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name            = "%s"
+  enabled         = true
+  password_hashes = ["%s"]
+  keys            = "~*"
+  channels        = "&*"
+  commands        = "+@all"
+}
+`, name, hash)
+}
+
+func TestAccACLUserResource_WriteOnlyPasswords(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigWriteOnlyPassword("wo_user", "password123", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckNoResourceAttr("redisacl_user.test", "passwords_wo"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "passwords_wo_version", "1"),
+					testAccCheckACLUserCanAuthenticate("wo_user", "password123"),
+				),
+			},
+			// Rotate the password by bumping the version
+			{
+				Config: testAccACLUserResourceConfigWriteOnlyPassword("wo_user", "newpassword456", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("redisacl_user.test", "passwords_wo"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "passwords_wo_version", "2"),
+					testAccCheckACLUserCanAuthenticate("wo_user", "newpassword456"),
+				),
+			},
+			// Without a version bump a new value is not sent to Redis
+			{
+				Config: testAccACLUserResourceConfigWriteOnlyPassword("wo_user", "ignoredpassword", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserCanAuthenticate("wo_user", "newpassword456"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckACLUserCanAuthenticate(username, password string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		ctx := context.Background()
+		ok, err := UserCanAuthenticate(ctx, username, password)
+		if err != nil {
+			return fmt.Errorf("Error authenticating as %s: %w", username, err)
+		}
+
+		if !ok {
+			return fmt.Errorf("ACL User %s cannot authenticate with the expected password", username)
+		}
+
+		return nil
+	}
+}
+
+func testAccACLUserResourceConfigWriteOnlyPassword(name, password string, version int) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name                 = "%s"
+  enabled              = true
+  passwords_wo         = ["%s"]
+  passwords_wo_version = %d
+  keys                 = "~*"
+  channels             = "&*"
+  commands             = "+@all"
+}
+`, name, password, version)
+}
+
+func TestAccACLUserResource_KeyPermissions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigKeyPermissions("keyperm_user", `["logs:*"]`, `["queue:*"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "read_keys.*", "logs:*"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "write_keys.*", "queue:*"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "readwrite_keys.*", "app:*"),
+				),
+			},
+			// Apply again to ensure no drift detected
+			{
+				Config:   testAccACLUserResourceConfigKeyPermissions("keyperm_user", `["logs:*"]`, `["queue:*"]`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccACLUserResourceConfigKeyPermissions("keyperm_user", `["logs:*", "audit:*"]`, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "read_keys.#", "2"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "write_keys.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigKeyPermissions(name, readKeys, writeKeys string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name           = "%s"
+  enabled        = true
+  readwrite_keys = ["app:*"]
+  read_keys      = %s
+  write_keys     = %s
+  channels       = "&*"
+  commands       = "+@all"
+}
+`, name, readKeys, writeKeys)
+}
+
+func TestAccACLUserResource_CommandPermissions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigCommandPermissions("cmdperm_user", `["read", "write"]`, `["keys"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "allowed_categories.*", "read"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "allowed_categories.*", "write"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "denied_categories.*", "dangerous"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "allowed_commands.*", "ping"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "denied_commands.*", "keys"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "allowed_subcommands.*", "config|get"),
+					resource.TestCheckNoResourceAttr("redisacl_user.test", "commands"),
+				),
+			},
+			// Apply again to ensure no drift detected
+			{
+				Config:   testAccACLUserResourceConfigCommandPermissions("cmdperm_user", `["read", "write"]`, `["keys"]`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccACLUserResourceConfigCommandPermissions("cmdperm_user", `["read"]`, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "allowed_categories.#", "1"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "denied_commands.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccACLUserResource_InvalidCommandPermissions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name             = "invalid_cmdperm_user"
+  allowed_commands = ["+get"]
+}
+`,
+				ExpectError: regexp.MustCompile(`must be a lowercase command name`),
+			},
+			{
+				Config: `
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name               = "invalid_cmdperm_user"
+  commands           = "+@all"
+  allowed_categories = ["read"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigCommandPermissions(name, allowedCategories, deniedCommands string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name                = "%s"
+  enabled             = true
+  keys                = "~*"
+  channels            = "&*"
+  allowed_categories  = %s
+  denied_categories   = ["dangerous"]
+  allowed_commands    = ["ping"]
+  denied_commands     = %s
+  allowed_subcommands = ["config|get"]
+}
+`, name, allowedCategories, deniedCommands)
+}
+
+func TestAccACLUserResource_SelectorBlocks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigSelectorBlocks("selector_user", "+get"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "selector.#", "2"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "selector.0.commands", "+get"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "selector.0.read_keys.*", "logs:*"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "selector.0.write_keys.*", "queue:*"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "selector.1.keys.*", "app:*"),
+					resource.TestCheckTypeSetElemAttr("redisacl_user.test", "selector.1.channels.*", "events"),
+				),
+			},
+			// Apply again to ensure no drift detected
+			{
+				Config:   testAccACLUserResourceConfigSelectorBlocks("selector_user", "+get"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccACLUserResourceConfigSelectorBlocks("selector_user", "-@all +get +mget"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "selector.0.commands", "-@all +get +mget"),
+				),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigSelectorBlocks(name, commands string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name     = "%s"
+  enabled  = true
+  keys     = "~*"
+  channels = "&*"
+  commands = "+ping"
+
+  selector {
+    commands   = "%s"
+    read_keys  = ["logs:*"]
+    write_keys = ["queue:*"]
+  }
+
+  selector {
+    commands = "+set"
+    keys     = ["app:*"]
+    channels = ["events"]
+  }
+}
+`, name, commands)
+}
+
+func TestAccACLUserResource_OnDestroyDisable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			ctx := context.Background()
+			exists, err := UserExists(ctx, "disabled_on_destroy_user")
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("ACL User disabled_on_destroy_user was deleted but should have been disabled")
+			}
+			ok, err := UserCanAuthenticate(ctx, "disabled_on_destroy_user", "secret123")
+			if err != nil {
+				return err
+			}
+			if ok {
+				return fmt.Errorf("ACL User disabled_on_destroy_user can still authenticate")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigOnDestroy("disabled_on_destroy_user", "disable"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "on_destroy", "disable"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccACLUserResource_OnDestroyRetain(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			ok, err := UserCanAuthenticate(context.Background(), "retained_user", "secret123")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("ACL User retained_user was changed on destroy")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigOnDestroy("retained_user", "retain"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "on_destroy", "retain"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccACLUserResource_InvalidOnDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccACLUserResourceConfigOnDestroy("invalid_on_destroy_user", "archive"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func TestAccACLUserResource_ProviderUserLockout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The provider authenticates as default, which already exists
+			{
+				Config:      testAccACLUserResourceConfigProviderUser("null", "delete"),
+				ExpectError: regexp.MustCompile(`would no longer be accepted`),
+			},
+			{
+				Config: testAccACLUserResourceConfigProviderUser(`["testpass"]`, "delete"),
+				Check:  testAccCheckACLUserCanAuthenticate("default", "testpass"),
+			},
+			{
+				Config:      testAccACLUserResourceConfigProviderUser(`["testpass"]`, "delete"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Refusing to delete ACL user default`),
+			},
+			{
+				Config: testAccACLUserResourceConfigProviderUser(`["testpass"]`, "disable"),
+			},
+			{
+				Config:      testAccACLUserResourceConfigProviderUser(`["testpass"]`, "disable"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Refusing to disable ACL user default`),
+			},
+			// Retaining the user only removes it from state
+			{
+				Config: testAccACLUserResourceConfigProviderUser(`["testpass"]`, "retain"),
+				Check:  testAccCheckACLUserCanAuthenticate("default", "testpass"),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigProviderUser(passwords, onDestroy string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name                = "default"
+  enabled             = true
+  passwords           = %s
+  keys                = "~*"
+  channels            = "&*"
+  commands            = "+@all"
+  allow_self_mutation = true
+  on_destroy          = "%s"
+}
+`, passwords, onDestroy)
+}
+
+func TestAccACLUserResource_UpgradeFromV0(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"redisacl": {
+						Source:            "B3ns44d/redisacl",
+						VersionConstraint: "1.0.2",
+					},
+				},
+				Config: testAccACLUserResourceConfigBasic("upgraded_user"),
+				Check:  testAccCheckACLUserExists("redisacl_user.test"),
+			},
+			// State written by 1.0.2 must not show a diff for on_destroy
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config:                   testAccACLUserResourceConfigBasic("upgraded_user"),
+				PlanOnly:                 true,
+			},
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config:                   testAccACLUserResourceConfigBasic("upgraded_user"),
+				Check:                    resource.TestCheckResourceAttr("redisacl_user.test", "on_destroy", "delete"),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigOnDestroy(name, onDestroy string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name       = "%s"
+  enabled    = true
+  passwords  = ["secret123"]
+  keys       = "~*"
+  channels   = "&*"
+  commands   = "+@all"
+  on_destroy = "%s"
+}
+`, name, onDestroy)
+}
+
+func TestAccACLUserResource_KillSessions(t *testing.T) {
+	var session *redis.Client
+	t.Cleanup(func() {
+		if session != nil {
+			_ = session.Close()
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigKillSessions("session_user", "oldpass"),
+				Check: func(_ *terraform.State) error {
+					var err error
+					session, err = NewUserClient(context.Background(), "session_user", "oldpass")
+					return err
+				},
+			},
+			{
+				Config: testAccACLUserResourceConfigKillSessions("session_user", "newpass"),
+				Check: func(_ *terraform.State) error {
+					// The open connection was killed, and reconnecting with
+					// the old password fails.
+					if err := session.Ping(context.Background()).Err(); err == nil {
+						return fmt.Errorf("session authenticated with the old password is still open")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigKillSessions(name, password string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name             = "%s"
+  enabled          = true
+  passwords        = ["%s"]
+  keys             = "~*"
+  channels         = "&*"
+  commands         = "+@all"
+  kill_sessions_on = ["password_change"]
+}
+`, name, password)
+}
+
+func TestAccACLUserResource_Assertions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLUserResourceConfigAssertions("assert_user", "denied"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "assert.#", "3"),
+					resource.TestCheckResourceAttr("redisacl_user.test", "assert.0.expect", "allowed"),
+				),
+			},
+			{
+				Config:      testAccACLUserResourceConfigAssertions("assert_user", "allowed"),
+				ExpectError: regexp.MustCompile(`Assertion Failed`),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigAssertions(name, setExpect string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name     = "%s"
+  enabled  = true
+  keys     = "~app:*"
+  channels = "&*"
+  commands = "+get"
+
+  assert {
+    command = ["GET", "app:1"]
+  }
+
+  assert {
+    command = ["GET", "other:1"]
+    expect  = "denied"
+  }
+
+  assert {
+    command = ["SET", "app:1", "value"]
+    expect  = "%s"
+  }
+}
+`, name, setExpect)
+}
+
+func TestAccACLUserResource_UnknownCommands(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccACLUserResourceConfigCommands("unknown_cmd_user", "-@all +get1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "\+get"\?`),
+			},
+			{
+				Config:      testAccACLUserResourceConfigCommands("unknown_cmd_user", "-@all +@reed"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "\+@read"\?`),
+			},
+			{
+				Config:      testAccACLUserResourceConfigCommands("unknown_cmd_user", "-@all +config|gte"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unknown Subcommand`),
+			},
+			{
+				Config: `
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name             = "unknown_cmd_user"
+  allowed_commands = ["hgett"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "hget"\?`),
+			},
+			{
+				Config: testAccACLUserResourceConfigCommands("unknown_cmd_user", "-@all +get +config|get +select|0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_user.test", "name", "unknown_cmd_user"),
+				),
+			},
+		},
+	})
+}
+
+func testAccACLUserResourceConfigCommands(name, commands string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name     = %[1]q
+  commands = %[2]q
+}
+`, name, commands)
+}
+
+func TestAccACLUserResource_InvalidRules(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccACLUserResourceConfigWithPermissions("invalid_rules_user", "~app:*", "&events", "+get ~pattern"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`The rule "~pattern" belongs in keys, not in commands`),
+			},
+			{
+				Config:      testAccACLUserResourceConfigWithPermissions("invalid_rules_user", "%X~app:*", "&events", "+get"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be %R~, %W~ or %RW~ followed by a key pattern`),
+			},
+			{
+				Config:      testAccACLUserResourceConfigWithPermissions("invalid_rules_user", "~app:*", "events", "+get"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is not a valid ACL rule for channels`),
+			},
+		},
+	})
+}
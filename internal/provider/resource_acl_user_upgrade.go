@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &ACLUserResource{}
+
+// aclUserResourceModelV0 describes the state written by provider versions up
+// to 1.0.2, before the schema was versioned.
+type aclUserResourceModelV0 struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	Passwords         types.List   `tfsdk:"passwords"`
+	Keys              types.String `tfsdk:"keys"`
+	Channels          types.String `tfsdk:"channels"`
+	Commands          types.String `tfsdk:"commands"`
+	Selectors         types.List   `tfsdk:"selectors"`
+	AllowSelfMutation types.Bool   `tfsdk:"allow_self_mutation"`
+}
+
+// aclUserSchemaV0 is the schema of version 0, used to decode the prior state.
+var aclUserSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":                  schema.StringAttribute{Computed: true},
+		"name":                schema.StringAttribute{Required: true},
+		"enabled":             schema.BoolAttribute{Optional: true},
+		"passwords":           schema.ListAttribute{ElementType: types.StringType, Optional: true, Sensitive: true},
+		"keys":                schema.StringAttribute{Optional: true},
+		"channels":            schema.StringAttribute{Optional: true},
+		"commands":            schema.StringAttribute{Optional: true},
+		"selectors":           schema.ListAttribute{ElementType: types.StringType, Optional: true},
+		"allow_self_mutation": schema.BoolAttribute{Optional: true},
+	},
+}
+
+// UpgradeState migrates state written before the schema was versioned. Those
+// versions always deleted the user on destroy, so on_destroy is set to
+// delete; the attributes added since then start out null.
+func (r *ACLUserResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &aclUserSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior aclUserResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradeACLUserStateV0(prior))...)
+			},
+		},
+	}
+}
+
+// upgradeACLUserStateV0 converts a version 0 state to the current model.
+func upgradeACLUserStateV0(prior aclUserResourceModelV0) ACLUserResourceModel {
+	return ACLUserResourceModel{
+		ID:                 prior.ID,
+		Name:               prior.Name,
+		Enabled:            prior.Enabled,
+		Passwords:          prior.Passwords,
+		PasswordHashes:     types.SetNull(types.StringType),
+		PasswordsWO:        types.ListNull(types.StringType),
+		PasswordsWOVersion: types.Int64Null(),
+		Keys:               prior.Keys,
+		ReadKeys:           types.SetNull(types.StringType),
+		WriteKeys:          types.SetNull(types.StringType),
+		ReadWriteKeys:      types.SetNull(types.StringType),
+		Channels:           prior.Channels,
+		Commands:           prior.Commands,
+		AllowedCommands:    types.SetNull(types.StringType),
+		DeniedCommands:     types.SetNull(types.StringType),
+		AllowedCategories:  types.SetNull(types.StringType),
+		DeniedCategories:   types.SetNull(types.StringType),
+		AllowedSubcommands: types.SetNull(types.StringType),
+		Selectors:          prior.Selectors,
+		AllowSelfMutation:  prior.AllowSelfMutation,
+		OnDestroy:          types.StringValue(onDestroyDelete),
+		KillSessionsOn:     types.SetNull(types.StringType),
+	}
+}
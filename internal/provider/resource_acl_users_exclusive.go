@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ACLUsersExclusiveResource{}
+var _ resource.ResourceWithImportState = &ACLUsersExclusiveResource{}
+var _ resource.ResourceWithModifyPlan = &ACLUsersExclusiveResource{}
+
+// usersExclusiveID is the ID of the redisacl_users_exclusive resource. There
+// is a single ACL user list per instance, so the ID is fixed.
+const usersExclusiveID = "users_exclusive"
+
+func NewACLUsersExclusiveResource() resource.Resource {
+	return &ACLUsersExclusiveResource{}
+}
+
+// ACLUsersExclusiveResource defines the resource implementation.
+type ACLUsersExclusiveResource struct {
+	redisClient *RedisClient
+}
+
+// ACLUsersExclusiveResourceModel describes the resource data model.
+type ACLUsersExclusiveResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Users        types.Set    `tfsdk:"users"`
+	IgnoredUsers types.Set    `tfsdk:"ignored_users"`
+}
+
+func (r *ACLUsersExclusiveResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users_exclusive"
+}
+
+func (r *ACLUsersExclusiveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Makes Terraform the single source of truth for the ACL users of a Redis instance. " +
+			"Users reported by `ACL USERS` that are not listed in `users` show up as drift and are deleted on apply. " +
+			"Creating the resource deletes nothing: undeclared users are only recorded, so they appear in the next plan before an apply deletes them. " +
+			"The `default` user and the user the provider is authenticated as are never deleted. " +
+			"Destroying this resource does not delete any user.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"users": schema.SetAttribute{
+				MarkdownDescription: "The names of every user that should exist, usually taken from `redisacl_user` resources.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"ignored_users": schema.SetAttribute{
+				MarkdownDescription: "Additional users that are kept even though they are not listed in `users`, such as users managed outside of Terraform.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ACLUsersExclusiveResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	redisClient, ok := req.ProviderData.(*RedisClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *RedisClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.redisClient = redisClient
+}
+
+// ModifyPlan names the users the apply will delete, which are otherwise only
+// visible as elements removed from users. When the resource is created, it
+// names the undeclared users that the next plan will propose to delete.
+func (r *ACLUsersExclusiveResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.redisClient == nil {
+		return
+	}
+
+	var plan ACLUsersExclusiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Users.IsUnknown() || plan.IgnoredUsers.IsUnknown() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		undeclared, err := r.undeclaredUsers(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Undeclared Users Not Listed",
+				fmt.Sprintf("Unable to list the ACL users that are not declared, got error: %s", err),
+			)
+			return
+		}
+		if len(undeclared) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Undeclared ACL Users Found",
+				fmt.Sprintf("The following ACL users are not declared: %s. Creating the resource does not delete them; "+
+					"they will appear in the next plan, and the apply after it will delete them.", strings.Join(undeclared, ", ")),
+			)
+		}
+		return
+	}
+
+	var state ACLUsersExclusiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleted, err := r.removedUsers(ctx, &state, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return
+	}
+	if len(deleted) > 0 {
+		resp.Diagnostics.AddWarning(
+			"ACL Users Will Be Deleted",
+			fmt.Sprintf("Applying this plan deletes the following ACL users, which are not declared: %s.", strings.Join(deleted, ", ")),
+		)
+	}
+}
+
+func (r *ACLUsersExclusiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ACLUsersExclusiveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Undeclared users are not deleted yet: the next refresh adds them to
+	// the state, so they show up in a plan before an apply deletes them.
+	data.ID = types.StringValue(usersExclusiveID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLUsersExclusiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ACLUsersExclusiveResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kept, err := r.keptUsers(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return
+	}
+
+	// Undeclared users are added to the state so that the plan shows them
+	// being removed.
+	users := map[string]bool{}
+	for _, user := range sortedStrings(data.Users) {
+		users[user] = true
+	}
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		names, err := node.Do(ctx, "ACL", "USERS").StringSlice()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if !kept[name] {
+				users[name] = true
+			}
+		}
+		return nil
+	})
+	if addNodeErrors(&resp.Diagnostics, errs, "list ACL users") {
+		return
+	}
+
+	data.Users = stringSet(users)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLUsersExclusiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ACLUsersExclusiveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	var state ACLUsersExclusiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the users the plan removed are deleted, so users created since the
+	// plan are left for the next one
+	deleted, err := r.removedUsers(ctx, &state, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get current user, got error: %s", err))
+		return
+	}
+	r.deleteUsers(ctx, deleted, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(usersExclusiveID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ACLUsersExclusiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Removing the resource only stops enforcing the user list; the users
+	// themselves are left in place.
+}
+
+func (r *ACLUsersExclusiveResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), usersExclusiveID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("users"), types.SetValueMust(types.StringType, []attr.Value{}))...)
+}
+
+// keptUsers returns the users that are never deleted: default, the user the
+// provider is authenticated as and the ignored users.
+func (r *ACLUsersExclusiveResource) keptUsers(ctx context.Context, data *ACLUsersExclusiveResourceModel) (map[string]bool, error) {
+	currentUser, err := r.redisClient.client.Do(ctx, "ACL", "WHOAMI").Text()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := map[string]bool{"default": true, currentUser: true}
+	for _, user := range sortedStrings(data.IgnoredUsers) {
+		kept[user] = true
+	}
+	return kept, nil
+}
+
+// undeclaredUsers returns the users found on any node that are neither
+// declared nor kept, sorted.
+func (r *ACLUsersExclusiveResource) undeclaredUsers(ctx context.Context, data *ACLUsersExclusiveResourceModel) ([]string, error) {
+	kept, err := r.keptUsers(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range sortedStrings(data.Users) {
+		kept[user] = true
+	}
+
+	undeclared := map[string]bool{}
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		names, err := node.Do(ctx, "ACL", "USERS").StringSlice()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if !kept[name] {
+				undeclared[name] = true
+			}
+		}
+		return nil
+	})
+	for _, e := range errs {
+		if !e.skipped {
+			return nil, fmt.Errorf("node %s: %w", e.addr, e.err)
+		}
+	}
+	return sortedStrings(stringSet(undeclared)), nil
+}
+
+// removedUsers returns the users of state that plan no longer lists, apart
+// from the users that are never deleted. Read adds undeclared users to the
+// state, so these are the users a plan shows being removed.
+func (r *ACLUsersExclusiveResource) removedUsers(ctx context.Context, state, plan *ACLUsersExclusiveResourceModel) ([]string, error) {
+	kept, err := r.keptUsers(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range sortedStrings(plan.Users) {
+		kept[user] = true
+	}
+
+	var removed []string
+	for _, user := range sortedStrings(state.Users) {
+		if !kept[user] {
+			removed = append(removed, user)
+		}
+	}
+	return removed, nil
+}
+
+// deleteUsers deletes users on every node. Users already missing from a node
+// are ignored by ACL DELUSER.
+func (r *ACLUsersExclusiveResource) deleteUsers(ctx context.Context, users []string, diags *diag.Diagnostics) {
+	if len(users) == 0 {
+		return
+	}
+
+	args := []interface{}{"ACL", "DELUSER"}
+	for _, user := range users {
+		args = append(args, user)
+	}
+	errs := r.redisClient.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		return node.Do(ctx, args...).Err()
+	})
+	if addNodeErrors(diags, errs, "delete undeclared ACL users") {
+		return
+	}
+
+	addPersistenceWarnings(diags, r.redisClient.persistACL(ctx), "The ACL user list")
+}
+
+func stringSet(values map[string]bool) types.Set {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	elements := make([]attr.Value, 0, len(names))
+	for _, name := range names {
+		elements = append(elements, types.StringValue(name))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccACLUsersExclusiveResource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if err := CreateTestUser(context.Background(), "stray_user", "testpass"); err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckACLUserDestroy,
+		Steps: []resource.TestStep{
+			// Creating the resource only records the undeclared users
+			{
+				Config: testAccACLUsersExclusiveResourceConfig("kept_user", `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("redisacl_users_exclusive.test", "id", "users_exclusive"),
+					resource.TestCheckResourceAttr("redisacl_users_exclusive.test", "users.#", "1"),
+					testAccCheckACLUserExists("redisacl_user.test"),
+					testAccCheckUserExists("stray_user"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// They show up in the next plan
+			{
+				Config:             testAccACLUsersExclusiveResourceConfig("kept_user", `[]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// And are deleted by the apply after it
+			{
+				Config: testAccACLUsersExclusiveResourceConfig("kept_user", `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					testAccCheckACLUserDoesNotExist("stray_user"),
+				),
+			},
+			// Apply again to ensure no drift detected
+			{
+				Config:   testAccACLUsersExclusiveResourceConfig("kept_user", `[]`),
+				PlanOnly: true,
+			},
+			// A user created outside of Terraform shows up as drift
+			{
+				PreConfig: func() {
+					if err := CreateTestUser(context.Background(), "another_stray_user", "testpass"); err != nil {
+						t.Fatalf("Failed to create test user: %v", err)
+					}
+				},
+				Config:             testAccACLUsersExclusiveResourceConfig("kept_user", `[]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Ignored users are left alone
+			{
+				Config: testAccACLUsersExclusiveResourceConfig("kept_user", `["another_stray_user"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckACLUserExists("redisacl_user.test"),
+					testAccCheckUserExists("another_stray_user"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserExists verifies that a user not managed by a resource
+// exists on the server.
+func testAccCheckUserExists(username string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		exists, err := UserExists(context.Background(), username)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("ACL user %s does not exist", username)
+		}
+		return nil
+	}
+}
+
+func testAccACLUsersExclusiveResourceConfig(name, ignoredUsers string) string {
+	return fmt.Sprintf(`
+provider "redisacl" {}
+
+resource "redisacl_user" "test" {
+  name     = "%s"
+  enabled  = true
+  keys     = "~*"
+  channels = "&*"
+  commands = "+@all"
+}
+
+resource "redisacl_users_exclusive" "test" {
+  users         = [redisacl_user.test.name]
+  ignored_users = %s
+}
+`, name, ignoredUsers)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Attributes an ACL rule can belong in. ruleProvider marks the rules the
+// provider emits itself, which cannot be written in any attribute.
+const (
+	ruleKeys      = "keys"
+	ruleChannels  = "channels"
+	ruleCommands  = "commands"
+	ruleSelectors = "selectors"
+	ruleEnabled   = "enabled"
+	rulePasswords = "passwords"
+	ruleProvider  = "provider"
+)
+
+// keyRuleRegexp matches the %R~, %W~ and %RW~ key rules.
+var keyRuleRegexp = regexp.MustCompile(`^%[RWrw]+~`)
+
+var _ validator.String = aclRulesValidator{}
+
+// aclRulesValidator checks, without a server connection, that every token of
+// a space-separated ACL rule string is well formed and belongs in the
+// attribute being validated.
+type aclRulesValidator struct {
+	// attribute is ruleKeys, ruleChannels, ruleCommands or ruleSelectors.
+	attribute string
+}
+
+// validACLRules returns a validator for the rules of attribute.
+func validACLRules(attribute string) aclRulesValidator {
+	return aclRulesValidator{attribute: attribute}
+}
+
+func (v aclRulesValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be space-separated ACL rules that belong in %s", v.attribute)
+}
+
+func (v aclRulesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v aclRulesValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, problem := range v.problems(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid ACL Rule", problem)
+	}
+}
+
+// problems returns a description of every token of rules that is malformed
+// or belongs in another attribute.
+func (v aclRulesValidator) problems(rules string) []string {
+	var problems []string
+	for _, token := range strings.Fields(rules) {
+		home, problem := classifyACLRule(token)
+		switch {
+		case v.attribute == ruleSelectors && (strings.HasPrefix(token, "(") || strings.HasSuffix(token, ")")):
+			problems = append(problems, fmt.Sprintf("The rule %q is in parentheses; selectors are written without them.", token))
+		case problem != "":
+			problems = append(problems, fmt.Sprintf("The rule %q %s.", token, problem))
+		case home == v.attribute:
+		case v.attribute == ruleSelectors && (home == ruleKeys || home == ruleChannels || home == ruleCommands):
+		case home == ruleProvider:
+			problems = append(problems, fmt.Sprintf("The rule %q is applied by the provider and cannot be used in %s.", token, v.attribute))
+		case home == "":
+			problems = append(problems, fmt.Sprintf("The rule %q is not a valid ACL rule for %s.", token, v.attribute))
+		default:
+			problems = append(problems, fmt.Sprintf("The rule %q belongs in %s, not in %s.", token, home, v.attribute))
+		}
+	}
+	return problems
+}
+
+// classifyACLRule returns the attribute an ACL rule belongs in, following
+// the ACL SETUSER grammar, and a description of what is wrong with it when it
+// is malformed. home is empty for tokens that are not ACL rules.
+func classifyACLRule(token string) (home, problem string) {
+	switch strings.ToLower(token) {
+	case "allkeys", "resetkeys":
+		return ruleKeys, ""
+	case "allchannels", "resetchannels":
+		return ruleChannels, ""
+	case "allcommands", "nocommands":
+		return ruleCommands, ""
+	case "on", "off":
+		return ruleEnabled, ""
+	case "nopass", "resetpass":
+		return rulePasswords, ""
+	case "reset", "clearselectors":
+		return ruleProvider, ""
+	}
+
+	switch token[0] {
+	case '~':
+		return ruleKeys, ""
+	case '%':
+		if !keyRuleRegexp.MatchString(token) {
+			return ruleKeys, "must be %R~, %W~ or %RW~ followed by a key pattern"
+		}
+		return ruleKeys, ""
+	case '&':
+		return ruleChannels, ""
+	case '+', '-':
+		return ruleCommands, commandRuleProblem(token[1:])
+	case '>', '<', '#', '!':
+		return rulePasswords, ""
+	case '(':
+		return ruleSelectors, ""
+	}
+	return "", ""
+}
+
+// commandRuleProblem describes what is wrong with the part of a +/- rule
+// after the sign, or returns an empty string when it is well formed.
+func commandRuleProblem(name string) string {
+	if category, ok := strings.CutPrefix(name, "@"); ok {
+		if category == "" || strings.ContainsAny(category, "@|") {
+			return "must be followed by a single category name"
+		}
+		return ""
+	}
+	if name == "" {
+		return "must be followed by a command name"
+	}
+	if command, subcommand, ok := strings.Cut(name, "|"); ok && (command == "" || subcommand == "" || strings.Contains(subcommand, "|")) {
+		return "must name a command and a single subcommand as command|subcommand"
+	}
+	return ""
+}
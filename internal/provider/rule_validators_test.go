@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACLRulesValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute string
+		rules     string
+		expected  []string
+	}{
+		{name: "keys", attribute: ruleKeys, rules: "~app:* %R~logs:* %W~queue:* %RW~cache:* allkeys resetkeys", expected: nil},
+		{name: "empty keys", attribute: ruleKeys, rules: "", expected: nil},
+		{name: "channels", attribute: ruleChannels, rules: "resetchannels &notifications:* allchannels", expected: nil},
+		{name: "commands", attribute: ruleCommands, rules: "-@all +@read -del +config|get -client|kill +GET allcommands nocommands", expected: nil},
+		{name: "selectors", attribute: ruleSelectors, rules: "~temp:* %R~logs:* &events:* +@read", expected: nil},
+		{
+			name:      "key pattern in commands",
+			attribute: ruleCommands,
+			rules:     "+get ~pattern",
+			expected:  []string{`The rule "~pattern" belongs in keys, not in commands.`},
+		},
+		{
+			name:      "command in keys",
+			attribute: ruleKeys,
+			rules:     "~app:* +get",
+			expected:  []string{`The rule "+get" belongs in commands, not in keys.`},
+		},
+		{
+			name:      "channel in keys",
+			attribute: ruleKeys,
+			rules:     "&events",
+			expected:  []string{`The rule "&events" belongs in channels, not in keys.`},
+		},
+		{
+			name:      "user rules",
+			attribute: ruleCommands,
+			rules:     "on >secret (~foo +get)",
+			expected: []string{
+				`The rule "on" belongs in enabled, not in commands.`,
+				`The rule ">secret" belongs in passwords, not in commands.`,
+				`The rule "(~foo" belongs in selectors, not in commands.`,
+			},
+		},
+		{
+			name:      "provider rules",
+			attribute: ruleCommands,
+			rules:     "reset +get",
+			expected:  []string{`The rule "reset" is applied by the provider and cannot be used in commands.`},
+		},
+		{
+			name:      "malformed key rule",
+			attribute: ruleKeys,
+			rules:     "%X~app:* %R",
+			expected: []string{
+				`The rule "%X~app:*" must be %R~, %W~ or %RW~ followed by a key pattern.`,
+				`The rule "%R" must be %R~, %W~ or %RW~ followed by a key pattern.`,
+			},
+		},
+		{
+			name:      "malformed command rules",
+			attribute: ruleCommands,
+			rules:     "+ -@ +@read|x +config| +|get +a|b|c",
+			expected: []string{
+				`The rule "+" must be followed by a command name.`,
+				`The rule "-@" must be followed by a single category name.`,
+				`The rule "+@read|x" must be followed by a single category name.`,
+				`The rule "+config|" must name a command and a single subcommand as command|subcommand.`,
+				`The rule "+|get" must name a command and a single subcommand as command|subcommand.`,
+				`The rule "+a|b|c" must name a command and a single subcommand as command|subcommand.`,
+			},
+		},
+		{
+			name:      "unknown token",
+			attribute: ruleChannels,
+			rules:     "notifications:*",
+			expected:  []string{`The rule "notifications:*" is not a valid ACL rule for channels.`},
+		},
+		{
+			name:      "parenthesized selector",
+			attribute: ruleSelectors,
+			rules:     "(~temp:* +get)",
+			expected: []string{
+				`The rule "(~temp:*" is in parentheses; selectors are written without them.`,
+				`The rule "+get)" is in parentheses; selectors are written without them.`,
+			},
+		},
+		{
+			name:      "password in selector",
+			attribute: ruleSelectors,
+			rules:     "~temp:* >secret",
+			expected:  []string{`The rule ">secret" belongs in passwords, not in selectors.`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validACLRules(tt.attribute).problems(tt.rules))
+		})
+	}
+}
+
+func TestACLRulesValidator_ValidateString(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  types.String
+		errors int
+	}{
+		{name: "null", value: types.StringNull(), errors: 0},
+		{name: "unknown", value: types.StringUnknown(), errors: 0},
+		{name: "valid", value: types.StringValue("+get +set"), errors: 0},
+		{name: "invalid", value: types.StringValue("~foo &bar"), errors: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("commands"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			validACLRules(ruleCommands).ValidateString(context.Background(), req, resp)
+			assert.Equal(t, tt.errors, resp.Diagnostics.ErrorsCount())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// sentinelTopology describes a master monitored by Redis Sentinel. ACL rules
+// are not replicated, so the provider uses it to reach the master and every
+// replica directly instead of going through the failover client.
+type sentinelTopology struct {
+	options *redis.FailoverOptions
+}
+
+// nodeAddrs asks the sentinels for the current master and its replicas and
+// returns their addresses, master first. Replicas that Sentinel reports as
+// down or disconnected are returned separately as skipped nodes; they are
+// neither updated nor read until they come back.
+func (s *sentinelTopology) nodeAddrs(ctx context.Context) ([]string, []nodeError, error) {
+	var lastErr error
+	for _, sentinelAddr := range s.options.SentinelAddrs {
+		addrs, skipped, err := s.queryNodeAddrs(ctx, sentinelAddr)
+		if err == nil {
+			return addrs, skipped, nil
+		}
+		lastErr = fmt.Errorf("sentinel %s: %w", sentinelAddr, err)
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no sentinel addresses configured")
+	}
+	return nil, nil, lastErr
+}
+
+func (s *sentinelTopology) queryNodeAddrs(ctx context.Context, sentinelAddr string) ([]string, []nodeError, error) {
+	sentinel := redis.NewSentinelClient(&redis.Options{
+		Addr:      sentinelAddr,
+		Username:  s.options.SentinelUsername,
+		Password:  s.options.SentinelPassword,
+		TLSConfig: s.options.TLSConfig,
+	})
+	defer func() { _ = sentinel.Close() }()
+
+	master, err := sentinel.GetMasterAddrByName(ctx, s.options.MasterName).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(master) != 2 {
+		return nil, nil, fmt.Errorf("unexpected master address reply %v", master)
+	}
+	addrs := []string{net.JoinHostPort(master[0], master[1])}
+
+	replicas, err := sentinel.Replicas(ctx, s.options.MasterName).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	var skipped []nodeError
+	for _, replica := range replicas {
+		addr := net.JoinHostPort(replica["ip"], replica["port"])
+		if isReplicaDown(replica["flags"]) {
+			skipped = append(skipped, nodeError{
+				addr:    addr,
+				err:     fmt.Errorf("reported as %s by Sentinel", replica["flags"]),
+				skipped: true,
+			})
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, skipped, nil
+}
+
+// newNodeClient connects directly to a single master or replica using the
+// credentials and TLS settings of the failover client.
+func (s *sentinelTopology) newNodeClient(addr string) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:      addr,
+		Username:  s.options.Username,
+		Password:  s.options.Password,
+		DB:        s.options.DB,
+		TLSConfig: s.options.TLSConfig,
+	})
+}
+
+func isReplicaDown(flags string) bool {
+	for _, flag := range strings.Split(flags, ",") {
+		switch flag {
+		case "s_down", "o_down", "disconnected":
+			return true
+		}
+	}
+	return false
+}
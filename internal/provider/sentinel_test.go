@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReplicaDown(t *testing.T) {
+	tests := []struct {
+		flags    string
+		expected bool
+	}{
+		{flags: "slave", expected: false},
+		{flags: "slave,s_down", expected: true},
+		{flags: "slave,o_down", expected: true},
+		{flags: "slave,disconnected", expected: true},
+		{flags: "slave,s_down,disconnected", expected: true},
+		{flags: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flags, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isReplicaDown(tt.flags))
+		})
+	}
+}
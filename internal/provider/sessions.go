@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// Events of the kill_sessions_on attribute.
+const (
+	killSessionsOnDisable        = "disable"
+	killSessionsOnPasswordChange = "password_change"
+	killSessionsOnAnyChange      = "any_change"
+	killSessionsOnDelete         = "delete"
+)
+
+var killSessionsEvents = []string{killSessionsOnDisable, killSessionsOnPasswordChange, killSessionsOnAnyChange, killSessionsOnDelete}
+
+// killSessionsOn reports whether event is listed in kill_sessions_on.
+func killSessionsOn(data *ACLUserResourceModel, event string) bool {
+	for _, value := range data.KillSessionsOn.Elements() {
+		if value.(types.String).ValueString() == event {
+			return true
+		}
+	}
+	return false
+}
+
+// killSessionsOnUpdate reports whether an update from state to data should
+// disconnect the clients authenticated as the user. passwordsSent tells
+// whether write-only passwords were sent to Redis.
+func killSessionsOnUpdate(state, data *ACLUserResourceModel, passwordsSent bool) bool {
+	passwordChanged := passwordsSent || !state.Passwords.Equal(data.Passwords) || !state.PasswordHashes.Equal(data.PasswordHashes)
+	disabled := data.Enabled.Equal(types.BoolValue(false)) && !state.Enabled.Equal(types.BoolValue(false))
+
+	switch {
+	case killSessionsOn(data, killSessionsOnAnyChange):
+		return passwordChanged || !aclUserMatches(state, data)
+	case killSessionsOn(data, killSessionsOnPasswordChange) && passwordChanged:
+		return true
+	case killSessionsOn(data, killSessionsOnDisable) && disabled:
+		return true
+	}
+	return false
+}
+
+// killSessionsOnDestroy reports whether destroying the resource should
+// disconnect the clients authenticated as the user.
+func killSessionsOnDestroy(data *ACLUserResourceModel) bool {
+	switch data.OnDestroy.ValueString() {
+	case onDestroyRetain:
+		return false
+	case onDestroyDisable:
+		return killSessionsOn(data, killSessionsOnDelete) || killSessionsOn(data, killSessionsOnDisable)
+	}
+	return killSessionsOn(data, killSessionsOnDelete)
+}
+
+// killSessions runs CLIENT KILL USER on every node and reports how many
+// connections were closed on each of them, including none.
+func (c *RedisClient) killSessions(ctx context.Context, name string, diags *diag.Diagnostics) {
+	killed := map[string]int64{}
+	errs := c.forEachNode(ctx, func(ctx context.Context, node *redis.Client) error {
+		n, err := node.ClientKillByFilter(ctx, "USER", name).Result()
+		if err != nil {
+			return err
+		}
+		killed[node.Options().Addr] = n
+		return nil
+	})
+	if addNodeErrors(diags, errs, "kill client sessions") {
+		return
+	}
+
+	diags.AddWarning("Client Sessions Killed", killedSessionsSummary(name, killed))
+}
+
+// killedSessionsSummary describes the number of connections closed on each
+// node, sorted by address.
+func killedSessionsSummary(name string, killed map[string]int64) string {
+	addrs := make([]string, 0, len(killed))
+	for addr := range killed {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	counts := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		counts = append(counts, fmt.Sprintf("%d on node %s", killed[addr], addr))
+	}
+	return fmt.Sprintf("Closed client connections authenticated as ACL user %s: %s.", name, strings.Join(counts, ", "))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKillSessionsOnUpdate(t *testing.T) {
+	events := func(values ...string) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+	user := func(enabled bool, password, keys string, killOn types.Set) *ACLUserResourceModel {
+		return &ACLUserResourceModel{
+			Enabled:            types.BoolValue(enabled),
+			Passwords:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue(password)}),
+			PasswordHashes:     types.SetNull(types.StringType),
+			Keys:               types.StringValue(keys),
+			ReadKeys:           types.SetNull(types.StringType),
+			WriteKeys:          types.SetNull(types.StringType),
+			ReadWriteKeys:      types.SetNull(types.StringType),
+			AllowedCommands:    types.SetNull(types.StringType),
+			DeniedCommands:     types.SetNull(types.StringType),
+			AllowedCategories:  types.SetNull(types.StringType),
+			DeniedCategories:   types.SetNull(types.StringType),
+			AllowedSubcommands: types.SetNull(types.StringType),
+			Selectors:          types.ListNull(types.StringType),
+			KillSessionsOn:     killOn,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		state         *ACLUserResourceModel
+		data          *ACLUserResourceModel
+		passwordsSent bool
+		expected      bool
+	}{
+		{
+			name:     "not configured",
+			state:    user(true, "a", "~*", types.SetNull(types.StringType)),
+			data:     user(false, "b", "~*", types.SetNull(types.StringType)),
+			expected: false,
+		},
+		{
+			name:     "disable on disable",
+			state:    user(true, "a", "~*", events("disable")),
+			data:     user(false, "a", "~*", events("disable")),
+			expected: true,
+		},
+		{
+			name:     "disable on password change",
+			state:    user(true, "a", "~*", events("disable")),
+			data:     user(true, "b", "~*", events("disable")),
+			expected: false,
+		},
+		{
+			name:     "password change",
+			state:    user(true, "a", "~*", events("password_change")),
+			data:     user(true, "b", "~*", events("password_change")),
+			expected: true,
+		},
+		{
+			name:          "write-only password change",
+			state:         user(true, "a", "~*", events("password_change")),
+			data:          user(true, "a", "~*", events("password_change")),
+			passwordsSent: true,
+			expected:      true,
+		},
+		{
+			name:     "any change on keys",
+			state:    user(true, "a", "~*", events("any_change")),
+			data:     user(true, "a", "~app:*", events("any_change")),
+			expected: true,
+		},
+		{
+			name:     "any change without rule changes",
+			state:    user(true, "a", "~*", events("any_change")),
+			data:     user(true, "a", "~*", events("any_change", "delete")),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, killSessionsOnUpdate(tt.state, tt.data, tt.passwordsSent))
+		})
+	}
+}
+
+func TestKillSessionsOnDestroy(t *testing.T) {
+	data := &ACLUserResourceModel{
+		OnDestroy:      types.StringValue(onDestroyDisable),
+		KillSessionsOn: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("disable")}),
+	}
+	assert.True(t, killSessionsOnDestroy(data))
+
+	data.OnDestroy = types.StringValue(onDestroyDelete)
+	assert.False(t, killSessionsOnDestroy(data))
+
+	data.KillSessionsOn = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("delete")})
+	assert.True(t, killSessionsOnDestroy(data))
+
+	data.OnDestroy = types.StringValue(onDestroyRetain)
+	assert.False(t, killSessionsOnDestroy(data))
+}
+
+func TestKilledSessionsSummary(t *testing.T) {
+	assert.Equal(t,
+		"Closed client connections authenticated as ACL user app: 2 on node 10.0.0.1:6379, 0 on node 10.0.0.2:6379.",
+		killedSessionsSummary("app", map[string]int64{"10.0.0.2:6379": 0, "10.0.0.1:6379": 2}),
+	)
+	assert.Equal(t,
+		"Closed client connections authenticated as ACL user app: 0 on node localhost:6379.",
+		killedSessionsSummary("app", map[string]int64{"localhost:6379": 0}),
+	)
+}
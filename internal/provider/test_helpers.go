@@ -7,13 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -78,6 +81,75 @@ func StartRedisContainer(ctx context.Context) error {
 	return nil
 }
 
+// StartConfigFileRedisContainer starts a separate Redis container from a
+// redis.conf, loading its users from an aclfile when withACLFile is set, and
+// returns it along with its connection string
+func StartConfigFileRedisContainer(ctx context.Context, withACLFile bool) (testcontainers.Container, string, error) {
+	config := "requirepass testpass\n"
+	var files []testcontainers.ContainerFile
+	if withACLFile {
+		config = "aclfile /data/users.acl\n"
+		files = append(files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader("user default on >testpass ~* &* +@all\n"),
+			ContainerFilePath: "/data/users.acl",
+			FileMode:          0o644,
+		})
+	}
+	files = append(files, testcontainers.ContainerFile{
+		Reader:            strings.NewReader(config),
+		ContainerFilePath: "/data/redis.conf",
+		FileMode:          0o644,
+	})
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7.4.7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		Cmd:          []string{"redis-server", "/data/redis.conf"},
+		Files:        files,
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Ready to accept connections"),
+			wait.ForListeningPort("6379/tcp"),
+		).WithDeadline(60 * time.Second),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to start Redis container: %w", err)
+	}
+
+	mappedPort, err := container.MappedPort(ctx, "6379")
+	if err != nil {
+		_ = container.Terminate(ctx)
+		return nil, "", fmt.Errorf("failed to get mapped port: %w", err)
+	}
+	host, err := container.Host(ctx)
+	if err != nil {
+		_ = container.Terminate(ctx)
+		return nil, "", fmt.Errorf("failed to get container host: %w", err)
+	}
+
+	return container, fmt.Sprintf("redis://default:testpass@%s:%s/0", host, mappedPort.Port()), nil
+}
+
+// ReadContainerFile returns the contents of a file in a container
+func ReadContainerFile(ctx context.Context, container testcontainers.Container, path string) (string, error) {
+	code, reader, err := container.Exec(ctx, []string{"cat", path}, tcexec.Multiplexed())
+	if err != nil {
+		return "", err
+	}
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	if code != 0 {
+		return "", fmt.Errorf("cat %s exited with code %d: %s", path, code, output)
+	}
+	return string(output), nil
+}
+
 // StopRedisContainer stops and removes the Redis container
 func StopRedisContainer(ctx context.Context) error {
 	if redisContainer == nil {
@@ -267,3 +339,133 @@ func ModifyUserInRedis(ctx context.Context, username string, rules []string) err
 
 	return client.ACLSetUser(ctx, username, rules...).Err()
 }
+
+// UserCanAuthenticate checks if a user can authenticate with the given password
+func UserCanAuthenticate(ctx context.Context, username, password string) (bool, error) {
+	if redisHost == "" || redisPort == "" {
+		return false, fmt.Errorf("redis container not started")
+	}
+
+	port, err := strconv.Atoi(redisPort)
+	if err != nil {
+		return false, fmt.Errorf("invalid port: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisHost, port),
+		Username: username,
+		Password: password,
+		DB:       0,
+	})
+	defer func() { _ = client.Close() }()
+
+	err = client.Ping(ctx).Err()
+	if err != nil {
+		if strings.Contains(err.Error(), "WRONGPASS") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetUserField returns a single field of ACL GETUSER for a user in Redis
+func GetUserField(ctx context.Context, username, field string) (interface{}, error) {
+	if redisHost == "" || redisPort == "" {
+		return nil, fmt.Errorf("redis container not started")
+	}
+
+	port, err := strconv.Atoi(redisPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisHost, port),
+		Password: "testpass",
+		DB:       0,
+	})
+	defer func() { _ = client.Close() }()
+
+	acl, err := getACLUser(ctx, client, username)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(acl); i += 2 {
+		if acl[i] == field {
+			return acl[i+1], nil
+		}
+	}
+	return nil, fmt.Errorf("field %s not found for user %s", field, username)
+}
+
+// NewUserClient opens a single connection to Redis authenticated as the given user
+func NewUserClient(ctx context.Context, username, password string) (*redis.Client, error) {
+	if redisHost == "" || redisPort == "" {
+		return nil, fmt.Errorf("redis container not started")
+	}
+
+	port, err := strconv.Atoi(redisPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:       fmt.Sprintf("%s:%d", redisHost, port),
+		Username:   username,
+		Password:   password,
+		PoolSize:   1,
+		MaxRetries: -1,
+	})
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// ACLLogLength returns the number of entries in the ACL log
+func ACLLogLength(ctx context.Context) (int, error) {
+	if redisHost == "" || redisPort == "" {
+		return 0, fmt.Errorf("redis container not started")
+	}
+
+	port, err := strconv.Atoi(redisPort)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisHost, port),
+		Password: "testpass",
+		DB:       0,
+	})
+	defer func() { _ = client.Close() }()
+
+	entries, err := client.Do(ctx, "ACL", "LOG", aclLogReadAll).Slice()
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// SetConfigInRedis sets a server parameter with CONFIG SET
+func SetConfigInRedis(ctx context.Context, parameter, value string) error {
+	if redisHost == "" || redisPort == "" {
+		return fmt.Errorf("redis container not started")
+	}
+
+	port, err := strconv.Atoi(redisPort)
+	if err != nil {
+		return fmt.Errorf("invalid port: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisHost, port),
+		Password: "testpass",
+		DB:       0,
+	})
+	defer func() { _ = client.Close() }()
+
+	return client.ConfigSet(ctx, parameter, value).Err()
+}